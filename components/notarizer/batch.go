@@ -0,0 +1,413 @@
+package notarizer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/labstack/echo/v4"
+
+	"github.com/adrian-grassl/inx-notarizer/pkg/batchstore"
+	"github.com/adrian-grassl/inx-notarizer/pkg/merkle"
+)
+
+// ParameterBatchID contains the ID of a previously submitted batch.
+const ParameterBatchID = "batchID"
+
+// ParameterLeafIndex contains the index of a leaf within a batch's Merkle tree.
+const ParameterLeafIndex = "leafIndex"
+
+// pendingBatch buffers incoming hashes until it is flushed into a single
+// on-chain output, amortizing the cost of one transaction over many
+// notarizations. It is persisted to the batch store as it is built, so a
+// restart - or a flush attempt that fails partway - does not silently lose
+// hashes a caller already received a batchID/leafIndex for.
+type pendingBatch struct {
+	id        string
+	hashes    []string
+	leaves    [][32]byte
+	createdAt time.Time
+}
+
+// batchManager owns the currently open pendingBatch and any batch awaiting a
+// flush retry (after a failed flush or a restart). Batches that have already
+// been flushed on-chain live only in the batch store.
+type batchManager struct {
+	mu       sync.Mutex
+	current  *pendingBatch
+	retrying []*pendingBatch
+	store    batchstore.Store
+}
+
+var batches = &batchManager{}
+
+// restorePendingBatches loads any batch left over from before a restart -
+// whether it was still buffering or had been handed off to a flush that
+// never finished - into the retry queue, so it gets flushed on the next tick
+// instead of its hashes being lost. New hashes start a fresh batch rather
+// than being appended to a recovered one, since callers may already be
+// polling a leafIndex into it.
+func restorePendingBatches() error {
+	store, err := getBatchStore()
+	if err != nil {
+		return err
+	}
+
+	pendingBatches, err := store.LoadPendingBatches()
+	if err != nil {
+		return err
+	}
+
+	batches.mu.Lock()
+	defer batches.mu.Unlock()
+
+	for batchID, pending := range pendingBatches {
+		batches.retrying = append(batches.retrying, &pendingBatch{
+			id:        batchID,
+			hashes:    pending.Hashes,
+			leaves:    pending.Leaves,
+			createdAt: time.Unix(pending.CreatedAt, 0),
+		})
+	}
+
+	return nil
+}
+
+// createBatchedNotarization appends hash to the currently open batch, opening
+// a new one if none is pending, and returns the batch ID and leaf index the
+// caller needs to later fetch its inclusion proof.
+func createBatchedNotarization(c echo.Context) error {
+	hash := c.Param(ParameterHash)
+	Logger.Debugf("Batched notarization hash: %s", hash)
+
+	batchID, leafIndex, err := batches.add(hash, merkle.LeafHash([]byte(hash)))
+	if err != nil {
+		Logger.Errorf("Error persisting batched notarization: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error persisting batched notarization")
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"batchID": batchID, "leafIndex": leafIndex})
+}
+
+// getBatchProof returns the Merkle inclusion proof for a leaf of an
+// already-flushed batch, so a caller can later prove their hash was included
+// in the batch's on-chain root without needing to remember the whole tree.
+func getBatchProof(c echo.Context) error {
+	store, err := getBatchStore()
+	if err != nil {
+		Logger.Errorf("Error opening batch store: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error opening batch store")
+	}
+
+	batchID := c.Param(ParameterBatchID)
+
+	leafIndex, err := strconv.Atoi(c.Param(ParameterLeafIndex))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid leafIndex")
+	}
+
+	flushedBatch, found, err := store.LoadBatch(batchID)
+	if err != nil {
+		Logger.Errorf("Error loading batch %s: %v", batchID, err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error loading batch")
+	}
+	if !found {
+		return echo.NewHTTPError(http.StatusNotFound, "Batch not found, or not yet flushed")
+	}
+	if leafIndex < 0 || leafIndex >= len(flushedBatch.Leaves) {
+		return echo.NewHTTPError(http.StatusBadRequest, "leafIndex out of range")
+	}
+
+	tree := merkle.New(flushedBatch.Leaves)
+	proof := tree.Proof(leafIndex)
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"proof":    proof,
+		"outputID": flushedBatch.OutputID,
+	})
+}
+
+// verifyBatchedNotarization recomputes a batch's Merkle root from a hash and
+// its inclusion proof and compares it against the MetadataFeature of the
+// given output, the same way verifyNotarization compares a plain hash string.
+func verifyBatchedNotarization(c echo.Context) error {
+	type requestBody struct {
+		Hash     string             `json:"hash"`
+		Proof    []merkle.ProofStep `json:"proof"`
+		OutputID string             `json:"outputID"`
+	}
+
+	var body requestBody
+
+	defer c.Request().Body.Close()
+
+	if err := json.NewDecoder(c.Request().Body).Decode(&body); err != nil {
+		Logger.Errorf("Error decoding batched verification request: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error decoding request body")
+	}
+
+	outputID, err := iotago.OutputIDFromHex(body.OutputID)
+	if err != nil {
+		Logger.Errorf("Error converting outputID string: %v", body.OutputID)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error converting outputID string")
+	}
+
+	ctx := c.Request().Context()
+
+	output, err := deps.INXNodeClient.OutputByID(ctx, outputID)
+	if err != nil {
+		Logger.Debug("No output found with passed outputID.")
+		return c.JSON(http.StatusOK, map[string]bool{"match": false})
+	}
+
+	basicOutput, ok := output.(*iotago.BasicOutput)
+	if !ok {
+		Logger.Error("Output is not of type *iotago.BasicOutput")
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unexpected output type")
+	}
+
+	metadataFeature := basicOutput.FeatureSet().MetadataFeature()
+	if metadataFeature == nil {
+		return c.JSON(http.StatusOK, map[string]bool{"match": false})
+	}
+
+	rootBytes, err := hex.DecodeString(string(metadataFeature.Data))
+	if err != nil || len(rootBytes) != 32 {
+		Logger.Error("MetadataFeature does not contain a valid Merkle root")
+		return c.JSON(http.StatusOK, map[string]bool{"match": false})
+	}
+	var root [32]byte
+	copy(root[:], rootBytes)
+
+	leaf := merkle.LeafHash([]byte(body.Hash))
+	match := merkle.VerifyProof(leaf, body.Proof, root)
+
+	return c.JSON(http.StatusOK, map[string]bool{"match": match})
+}
+
+// add appends hash and its leaf to the currently open batch, opening a new
+// one if needed, and returns its batch ID and leaf index. The batch is
+// persisted before add returns; if persistence fails, add returns that error
+// instead of the batchID/leafIndex, so a caller is never handed a receipt for
+// data that was never durably written. The hash still stays in the in-memory
+// batch, so a later successful persist (or the eventual flush) can still pick
+// it up - only the caller of this failed add is told it cannot yet rely on
+// it surviving a restart.
+func (m *batchManager) add(hash string, leaf [32]byte) (string, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.current == nil {
+		m.current = &pendingBatch{id: newBatchID(), createdAt: time.Now()}
+	}
+
+	m.current.hashes = append(m.current.hashes, hash)
+	m.current.leaves = append(m.current.leaves, leaf)
+
+	if err := m.persistPendingLocked(m.current); err != nil {
+		return "", 0, fmt.Errorf("failed to persist pending batch %s: %w", m.current.id, err)
+	}
+
+	return m.current.id, len(m.current.leaves) - 1, nil
+}
+
+// flushIfDue returns the next batch that should be flushed, or nil if none
+// is due. Batches awaiting a flush retry always take priority over the
+// currently open batch, so a failing downstream (node/indexer) doesn't starve
+// batches that already failed once.
+func (m *batchManager) flushIfDue() *pendingBatch {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.retrying) > 0 {
+		retry := m.retrying[0]
+		m.retrying = m.retrying[1:]
+
+		return retry
+	}
+
+	if m.current == nil || len(m.current.leaves) == 0 {
+		return nil
+	}
+
+	due := len(m.current.leaves) >= ParamsRestAPI.BatchMaxSize || time.Since(m.current.createdAt) >= ParamsRestAPI.BatchMaxWait
+	if !due {
+		return nil
+	}
+
+	flushed := m.current
+	m.current = nil
+
+	return flushed
+}
+
+// requeue puts pending back on the retry queue after a flush attempt failed,
+// so runBatchFlusher tries it again on a later tick instead of its hashes
+// being lost.
+func (m *batchManager) requeue(pending *pendingBatch) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.retrying = append(m.retrying, pending)
+}
+
+// persistPendingLocked writes pending's current state to the batch store.
+// Callers must hold m.mu.
+func (m *batchManager) persistPendingLocked(pending *pendingBatch) error {
+	store, err := m.resolveStore()
+	if err != nil {
+		return err
+	}
+
+	return store.SavePending(pending.id, batchstore.PendingBatch{
+		Hashes:    pending.hashes,
+		Leaves:    pending.leaves,
+		CreatedAt: pending.createdAt.Unix(),
+	})
+}
+
+// resolveStore returns m.store if one was injected (as tests do), falling
+// back to the package's lazily-initialized default store otherwise.
+func (m *batchManager) resolveStore() (batchstore.Store, error) {
+	if m.store != nil {
+		return m.store, nil
+	}
+
+	return getBatchStore()
+}
+
+// runBatchFlusher periodically checks whether the open batch is due for a
+// flush and, if so, anchors its Merkle root in a single notarization
+// transaction. It is meant to run as a BackgroundWorker tied to the
+// Component's Daemon lifecycle.
+func runBatchFlusher(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			flushed := batches.flushIfDue()
+			if flushed == nil {
+				continue
+			}
+
+			if err := flushBatch(ctx, flushed); err != nil {
+				Logger.Errorf("Error flushing batch %s, will retry: %v", flushed.id, err)
+				batches.requeue(flushed)
+			}
+		}
+	}
+}
+
+// flushBatch builds the Merkle tree for a pending batch, anchors its root
+// on-chain and persists the flushed batch so inclusion proofs remain
+// servable afterwards.
+func flushBatch(ctx context.Context, pending *pendingBatch) error {
+	tree := merkle.New(pending.leaves)
+	root := tree.Root()
+	hexRoot := hex.EncodeToString(root[:])
+
+	configuredSigner, err := configuredSigner()
+	if err != nil {
+		return err
+	}
+
+	protoParas := deps.NodeBridge.ProtocolParameters()
+
+	walletObject, err := prepWallet(protoParas, configuredSigner)
+	if err != nil {
+		return err
+	}
+
+	indexerResultSet, err := fetchOutputsByAddress(walletObject.Bech32Address)
+	if err != nil {
+		return err
+	}
+
+	unspentOutputs, err := filterOutputs(indexerResultSet)
+	if err != nil {
+		return err
+	}
+
+	txPayload, err := prepTxPayload(protoParas, unspentOutputs, walletObject.Ed25519Address, walletObject.AddressSigner, hexRoot)
+	if err != nil {
+		return err
+	}
+
+	transactionID, err := txPayload.ID()
+	if err != nil {
+		return err
+	}
+	outputID := iotago.OutputIDFromTransactionIDAndIndex(transactionID, 0)
+
+	hexBlockID, err := prepAndSendBlockCtx(ctx, protoParas, txPayload)
+	if err != nil {
+		return err
+	}
+	Logger.Infof("Batch %s flushed, root %s anchored at output %s", pending.id, hexRoot, outputID.ToHex())
+
+	store, err := getBatchStore()
+	if err != nil {
+		return err
+	}
+
+	if err := store.SaveBatch(pending.id, batchstore.FlushedBatch{
+		Root:     root,
+		Leaves:   pending.leaves,
+		OutputID: outputID.ToHex(),
+	}); err != nil {
+		return err
+	}
+
+	if err := store.DeletePending(pending.id); err != nil {
+		Logger.Errorf("Error deleting persisted pending batch %s after flush: %v", pending.id, err)
+	}
+
+	for leafIndex, hash := range pending.hashes {
+		leafIndex := leafIndex
+		recordAnchor(hash, txPayload, hexBlockID, &pending.id, &leafIndex)
+	}
+
+	return nil
+}
+
+var (
+	batchStoreOnce sync.Once
+	batchStore     batchstore.Store
+	batchStoreErr  error
+)
+
+// getBatchStore lazily creates the batch store for the configured
+// BatchStore.Backend.
+func getBatchStore() (batchstore.Store, error) {
+	batchStoreOnce.Do(func() {
+		switch ParamsRestAPI.BatchStore.Backend {
+		case "bolt":
+			batchStore, batchStoreErr = batchstore.NewBoltStore(ParamsRestAPI.BatchStore.Bolt.Path)
+		case "file":
+			batchStore, batchStoreErr = batchstore.NewFileStore(ParamsRestAPI.BatchStore.File.Directory)
+		default:
+			batchStoreErr = fmt.Errorf("unknown batch store backend %q", ParamsRestAPI.BatchStore.Backend)
+		}
+	})
+
+	return batchStore, batchStoreErr
+}
+
+// newBatchID generates a random, URL-safe batch identifier.
+func newBatchID() string {
+	raw := make([]byte, 8)
+	_, _ = rand.Read(raw)
+
+	return hex.EncodeToString(raw)
+}
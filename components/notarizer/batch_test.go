@@ -0,0 +1,121 @@
+package notarizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adrian-grassl/inx-notarizer/pkg/batchstore"
+	"github.com/adrian-grassl/inx-notarizer/pkg/merkle"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestBatchManager returns a batchManager backed by a throwaway file store
+// rooted in t.TempDir(), so tests never touch the process's real batch store
+// directory.
+func newTestBatchManager(t *testing.T) *batchManager {
+	t.Helper()
+
+	store, err := batchstore.NewFileStore(t.TempDir())
+	assert.NoError(t, err)
+
+	return &batchManager{store: store}
+}
+
+func TestBatchManagerAdd(t *testing.T) {
+	t.Run("Successive adds share the same batch and increment leaf index", func(t *testing.T) {
+		manager := newTestBatchManager(t)
+
+		batchIDA, leafIndexA, err := manager.add("a", merkle.LeafHash([]byte("a")))
+		assert.NoError(t, err)
+		batchIDB, leafIndexB, err := manager.add("b", merkle.LeafHash([]byte("b")))
+		assert.NoError(t, err)
+
+		assert.Equal(t, batchIDA, batchIDB)
+		assert.Equal(t, 0, leafIndexA)
+		assert.Equal(t, 1, leafIndexB)
+	})
+
+	t.Run("add persists the pending batch so it survives a restart", func(t *testing.T) {
+		manager := newTestBatchManager(t)
+
+		batchID, _, err := manager.add("a", merkle.LeafHash([]byte("a")))
+		assert.NoError(t, err)
+		_, _, err = manager.add("b", merkle.LeafHash([]byte("b")))
+		assert.NoError(t, err)
+
+		pendingBatches, err := manager.store.LoadPendingBatches()
+		assert.NoError(t, err)
+		if assert.Contains(t, pendingBatches, batchID) {
+			assert.Equal(t, []string{"a", "b"}, pendingBatches[batchID].Hashes)
+		}
+	})
+
+	t.Run("A persistence failure is returned instead of a batchID/leafIndex", func(t *testing.T) {
+		manager := &batchManager{store: failingStore{}}
+
+		batchID, leafIndex, err := manager.add("a", merkle.LeafHash([]byte("a")))
+		assert.Error(t, err)
+		assert.Empty(t, batchID)
+		assert.Zero(t, leafIndex)
+	})
+}
+
+// failingStore is a batchstore.Store whose SavePending always fails, so
+// tests can exercise add's error path without a real disk-full/permission
+// failure.
+type failingStore struct {
+	batchstore.Store
+}
+
+func (failingStore) SavePending(string, batchstore.PendingBatch) error {
+	return assert.AnError
+}
+
+func TestBatchManagerFlushIfDue(t *testing.T) {
+	t.Run("Not due before BatchMaxSize or BatchMaxWait are reached", func(t *testing.T) {
+		ParamsRestAPI.BatchMaxSize = 10
+		ParamsRestAPI.BatchMaxWait = time.Hour
+
+		manager := newTestBatchManager(t)
+		manager.add("a", merkle.LeafHash([]byte("a")))
+
+		assert.Nil(t, manager.flushIfDue())
+	})
+
+	t.Run("Due once BatchMaxSize is reached", func(t *testing.T) {
+		ParamsRestAPI.BatchMaxSize = 1
+		ParamsRestAPI.BatchMaxWait = time.Hour
+
+		manager := newTestBatchManager(t)
+		manager.add("a", merkle.LeafHash([]byte("a")))
+
+		flushed := manager.flushIfDue()
+		assert.NotNil(t, flushed)
+		assert.Nil(t, manager.current)
+	})
+
+	t.Run("Retrying batches are returned before the current batch", func(t *testing.T) {
+		ParamsRestAPI.BatchMaxSize = 1
+		ParamsRestAPI.BatchMaxWait = time.Hour
+
+		manager := newTestBatchManager(t)
+		manager.add("a", merkle.LeafHash([]byte("a")))
+
+		retry := &pendingBatch{id: "retry-me"}
+		manager.requeue(retry)
+
+		assert.Same(t, retry, manager.flushIfDue())
+	})
+}
+
+func TestBatchManagerRequeue(t *testing.T) {
+	t.Run("A failed flush is retried rather than lost", func(t *testing.T) {
+		manager := newTestBatchManager(t)
+
+		failed := &pendingBatch{id: "batch1", hashes: []string{"a"}, leaves: [][32]byte{merkle.LeafHash([]byte("a"))}}
+		manager.requeue(failed)
+
+		assert.Same(t, failed, manager.flushIfDue())
+		assert.Nil(t, manager.flushIfDue())
+	})
+}
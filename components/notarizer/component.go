@@ -10,6 +10,7 @@ import (
 
 	"go.uber.org/dig"
 
+	"github.com/adrian-grassl/inx-notarizer/components/notarizer/grpc"
 	"github.com/adrian-grassl/inx-notarizer/pkg/daemon"
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/inx-app/pkg/httpserver"
@@ -49,6 +50,33 @@ func provide(c *dig.Container) error {
 }
 
 func run() error {
+	// recover any batch left buffering - or mid-flush - from before a restart
+	// so its hashes are retried instead of lost
+	if err := restorePendingBatches(); err != nil {
+		Component.LogPanicf("failed to restore pending notarization batches: %s", err)
+	}
+
+	// create a background worker that flushes pending notarization batches
+	if err := Component.Daemon().BackgroundWorker("BatchFlusher", func(ctx context.Context) {
+		Component.LogInfo("Starting batch flusher ... done")
+		runBatchFlusher(ctx)
+		Component.LogInfo("Stopping batch flusher ... done")
+	}, daemon.PriorityStopRestAPI); err != nil {
+		Component.LogPanicf("failed to start worker: %s", err)
+	}
+
+	if ParamsRestAPI.IndexBackfillEnabled {
+		// create a background worker that backfills the local notarization
+		// index from NodeBridge ledger updates
+		if err := Component.Daemon().BackgroundWorker("IndexBackfill", func(ctx context.Context) {
+			Component.LogInfo("Starting index backfill ... done")
+			runIndexBackfill(ctx)
+			Component.LogInfo("Stopping index backfill ... done")
+		}, daemon.PriorityStopRestAPI); err != nil {
+			Component.LogPanicf("failed to start worker: %s", err)
+		}
+	}
+
 	// create a background worker that handles the API
 	if err := Component.Daemon().BackgroundWorker("API", func(ctx context.Context) {
 		Component.LogInfo("Starting API ... done")
@@ -108,13 +136,72 @@ func run() error {
 		Component.LogPanicf("failed to start worker: %s", err)
 	}
 
+	// create a background worker that closes the cached signer backend's
+	// connection (if any) once the process is shutting down, so the
+	// long-lived gRPC connection the remote/KMS backends dial once and reuse
+	// doesn't leak past the process's own lifetime.
+	if err := Component.Daemon().BackgroundWorker("SignerCleanup", func(ctx context.Context) {
+		<-ctx.Done()
+		if err := closeSigner(); err != nil {
+			Component.LogWarnf("Closing signer backend failed: %s", err)
+		}
+	}, daemon.PriorityStopRestAPI); err != nil {
+		Component.LogPanicf("failed to start worker: %s", err)
+	}
+
+	if ParamsGRPC.Enabled {
+		// create a background worker that handles the gRPC API
+		if err := Component.Daemon().BackgroundWorker("GRPCAPI", func(ctx context.Context) {
+			Component.LogInfo("Starting gRPC API ... done")
+
+			grpcServer, err := grpc.NewServer(ParamsGRPC.BindAddress, grpcBackend{})
+			if err != nil {
+				Component.LogErrorfAndExit("Starting gRPC server failed: %s", err)
+			}
+
+			go func() {
+				Component.LogInfof("You can now access the gRPC API using: %s", ParamsGRPC.BindAddress)
+				if err := grpcServer.Serve(); err != nil {
+					Component.LogWarnf("gRPC server stopped serving: %s", err)
+				}
+			}()
+
+			<-ctx.Done()
+			Component.LogInfo("Stopping gRPC API ...")
+			grpcServer.Stop()
+			Component.LogInfo("Stopping gRPC API ... done")
+		}, daemon.PriorityStopRestAPI); err != nil {
+			Component.LogPanicf("failed to start worker: %s", err)
+		}
+	}
+
 	return nil
 }
 
+// grpcBackend adapts the package's exported Create/Verify functions to the
+// grpc.Backend interface, keeping package grpc free of an import cycle back
+// to this package.
+type grpcBackend struct{}
+
+func (grpcBackend) Create(ctx context.Context, hash string) (string, error) {
+	return Create(ctx, hash)
+}
+
+func (grpcBackend) Verify(ctx context.Context, hash string, outputIDHex string) (bool, error) {
+	return Verify(ctx, hash, outputIDHex)
+}
+
+// LoadEnvVariables loads MNEMONIC from a .env file when the "mnemonic" signer
+// backend is selected. It is a no-op for every other backend, since they keep
+// the signing key out of the notarizer process entirely and have no use for
+// a .env file. A missing .env file is not fatal: MNEMONIC may already be set
+// in the process environment without one.
 func LoadEnvVariables() {
-	err := godotenv.Load()
+	if ParamsRestAPI.Signer.Backend != "" && ParamsRestAPI.Signer.Backend != "mnemonic" {
+		return
+	}
 
-	if err != nil {
-		log.Fatal("Error loading .env file")
+	if err := godotenv.Load(); err != nil {
+		log.Printf("No .env file loaded, falling back to the process environment: %s", err)
 	}
 }
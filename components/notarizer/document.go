@@ -0,0 +1,244 @@
+package notarizer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/labstack/echo/v4"
+
+	"github.com/adrian-grassl/inx-notarizer/pkg/documentstore"
+)
+
+// NotarizationDocument is a full off-chain document whose hash is anchored
+// on-chain, modeled after the richer metadata that sits behind a Solidity
+// contract's NatSpec hash.
+type NotarizationDocument struct {
+	Title     string          `json:"title"`
+	Author    string          `json:"author"`
+	MimeType  string          `json:"mimeType"`
+	Payload   json.RawMessage `json:"payload"`
+	Timestamp int64           `json:"timestamp"`
+	Signature string          `json:"signature,omitempty"`
+}
+
+// documentVerificationRequest is the payload accepted by
+// RouteVerifyDocumentNotarization.
+type documentVerificationRequest struct {
+	Document NotarizationDocument `json:"document"`
+	OutputID string               `json:"outputID"`
+}
+
+var (
+	documentStoreOnce sync.Once
+	documentStore     documentstore.Store
+	documentStoreErr  error
+)
+
+// getDocumentStore lazily creates the document store for the configured
+// DocumentStore.Backend.
+func getDocumentStore() (documentstore.Store, error) {
+	documentStoreOnce.Do(func() {
+		switch ParamsRestAPI.DocumentStore.Backend {
+		case "bolt":
+			documentStore, documentStoreErr = documentstore.NewBoltStore(ParamsRestAPI.DocumentStore.Bolt.Path)
+		case "file":
+			documentStore, documentStoreErr = documentstore.NewFileStore(ParamsRestAPI.DocumentStore.File.Directory)
+		default:
+			documentStoreErr = fmt.Errorf("unknown document store backend %q", ParamsRestAPI.DocumentStore.Backend)
+		}
+	})
+
+	return documentStore, documentStoreErr
+}
+
+// createDocumentNotarization accepts a full notarization document, persists it
+// in the document store and anchors only its hash on-chain, the same way
+// createNotarization anchors a caller-supplied hash string.
+func createDocumentNotarization(c echo.Context) error {
+	var document NotarizationDocument
+
+	defer c.Request().Body.Close()
+
+	if err := json.NewDecoder(c.Request().Body).Decode(&document); err != nil {
+		Logger.Errorf("Error decoding notarization document: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error decoding request body")
+	}
+
+	hexDocumentHash, documentBytes, err := hashDocument(document)
+	if err != nil {
+		Logger.Errorf("Error hashing notarization document: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error hashing notarization document")
+	}
+	Logger.Debugf("Document hash: %s", hexDocumentHash)
+
+	store, err := getDocumentStore()
+	if err != nil {
+		Logger.Errorf("Error opening document store: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error opening document store")
+	}
+
+	if err := store.Put(hexDocumentHash, documentBytes); err != nil {
+		Logger.Errorf("Error persisting notarization document: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error persisting notarization document")
+	}
+
+	configuredSigner, err := configuredSigner()
+	if err != nil {
+		Logger.Errorf("Error resolving signer backend: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error resolving signer backend")
+	}
+
+	protoParas := deps.NodeBridge.ProtocolParameters()
+
+	walletObject, err := prepWallet(protoParas, configuredSigner)
+	if err != nil {
+		Logger.Errorf("Error preparing wallet: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error preparing wallet")
+	}
+
+	indexerResultSet, err := fetchOutputsByAddress(walletObject.Bech32Address)
+	if err != nil {
+		Logger.Errorf("Error fetching outputs: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error fetching outputs")
+	}
+
+	unspentOutputs, err := filterOutputs(indexerResultSet)
+	if err != nil {
+		Logger.Errorf("Error filtering outputs: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error filtering outputs")
+	}
+
+	txPayload, err := prepTxPayload(protoParas, unspentOutputs, walletObject.Ed25519Address, walletObject.AddressSigner, hexDocumentHash)
+	if err != nil {
+		Logger.Errorf("Error preparing transaction payload: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error preparing transaction payload")
+	}
+
+	hexBlockId, err := prepAndSendBlock(c, protoParas, txPayload)
+	if err != nil {
+		Logger.Errorf("Error preparing and sending block: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error preparing and sending block")
+	}
+	Logger.Infof("Block attached with ID: %v", hexBlockId)
+
+	recordAnchor(hexDocumentHash, txPayload, hexBlockId, nil, nil)
+
+	return c.JSON(http.StatusOK, map[string]string{"blockId": hexBlockId, "hash": hexDocumentHash})
+}
+
+// getDocumentNotarization serves the full notarization document previously
+// persisted under the given hash, so a caller holding only the on-chain
+// anchored hash can retrieve the document behind it.
+func getDocumentNotarization(c echo.Context) error {
+	hash := c.Param(ParameterHash)
+
+	store, err := getDocumentStore()
+	if err != nil {
+		Logger.Errorf("Error opening document store: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error opening document store")
+	}
+
+	documentBytes, err := store.Get(hash)
+	if err != nil {
+		Logger.Debugf("No document stored for hash %s: %v", hash, err)
+		return echo.NewHTTPError(http.StatusNotFound, "No document stored for hash")
+	}
+
+	return c.JSONBlob(http.StatusOK, documentBytes)
+}
+
+// verifyDocumentNotarization re-derives a document's hash and compares it
+// against the MetadataFeature of the given output, the same way
+// verifyNotarization compares a plain hash string.
+func verifyDocumentNotarization(c echo.Context) error {
+	var requestBody documentVerificationRequest
+
+	defer c.Request().Body.Close()
+
+	if err := json.NewDecoder(c.Request().Body).Decode(&requestBody); err != nil {
+		Logger.Errorf("Error decoding document verification request: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error decoding request body")
+	}
+
+	outputID, err := iotago.OutputIDFromHex(requestBody.OutputID)
+	if err != nil {
+		Logger.Errorf("Error converting outputID string: %v", requestBody.OutputID)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error converting outputID string")
+	}
+
+	hexDocumentHash, _, err := hashDocument(requestBody.Document)
+	if err != nil {
+		Logger.Errorf("Error hashing notarization document: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error hashing notarization document")
+	}
+
+	ctx := c.Request().Context()
+
+	output, err := deps.INXNodeClient.OutputByID(ctx, outputID)
+	if err != nil {
+		Logger.Debug("No output found with passed outputID.")
+		return c.JSON(http.StatusOK, map[string]bool{"match": false})
+	}
+
+	basicOutput, ok := output.(*iotago.BasicOutput)
+	if !ok {
+		Logger.Error("Output is not of type *iotago.BasicOutput")
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unexpected output type")
+	}
+
+	metadataFeature := basicOutput.FeatureSet().MetadataFeature()
+	if metadataFeature != nil && string(metadataFeature.Data) == hexDocumentHash {
+		Logger.Debugf("Matching document hash found: %v", hexDocumentHash)
+		return c.JSON(http.StatusOK, map[string]bool{"match": true})
+	}
+
+	Logger.Debug("No matching metadata feature found in output.")
+	return c.JSON(http.StatusOK, map[string]bool{"match": false})
+}
+
+// hashDocument computes the SHA-256 hash of document's canonical encoding and
+// also returns its plain JSON encoding so that can be persisted as-is.
+func hashDocument(document NotarizationDocument) (string, []byte, error) {
+	documentBytes, err := json.Marshal(document)
+	if err != nil {
+		return "", nil, err
+	}
+
+	canonicalBytes, err := canonicalizeDocument(document)
+	if err != nil {
+		return "", nil, err
+	}
+
+	digest := sha256.Sum256(canonicalBytes)
+
+	return hex.EncodeToString(digest[:]), documentBytes, nil
+}
+
+// canonicalizeDocument canonically encodes document the same way
+// hashTypedMessage canonicalizes a typed message, so that Payload - a
+// json.RawMessage carried through encoding/json verbatim, whitespace, key
+// order and all - doesn't change the hash of an otherwise logically
+// identical document. Without this, create and verify could disagree on the
+// hash of the same payload reserialized with different key order.
+func canonicalizeDocument(document NotarizationDocument) ([]byte, error) {
+	var payload any
+	if len(document.Payload) > 0 {
+		if err := json.Unmarshal(document.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode document payload: %v", err)
+		}
+	}
+
+	return canonicalEncode(map[string]any{
+		"title":     document.Title,
+		"author":    document.Author,
+		"mimeType":  document.MimeType,
+		"payload":   payload,
+		"timestamp": float64(document.Timestamp),
+		"signature": document.Signature,
+	})
+}
@@ -0,0 +1,69 @@
+package notarizer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashDocument(t *testing.T) {
+	t.Run("Payload key order does not affect the hash", func(t *testing.T) {
+		docA := NotarizationDocument{Title: "doc", Payload: json.RawMessage(`{"a":1,"b":2}`)}
+		docB := NotarizationDocument{Title: "doc", Payload: json.RawMessage(`{"b":2,"a":1}`)}
+
+		hashA, _, err := hashDocument(docA)
+		assert.NoError(t, err)
+		hashB, _, err := hashDocument(docB)
+		assert.NoError(t, err)
+
+		assert.Equal(t, hashA, hashB)
+	})
+
+	t.Run("Payload whitespace does not affect the hash", func(t *testing.T) {
+		docA := NotarizationDocument{Title: "doc", Payload: json.RawMessage(`{"a":1}`)}
+		docB := NotarizationDocument{Title: "doc", Payload: json.RawMessage("{\n  \"a\": 1\n}")}
+
+		hashA, _, err := hashDocument(docA)
+		assert.NoError(t, err)
+		hashB, _, err := hashDocument(docB)
+		assert.NoError(t, err)
+
+		assert.Equal(t, hashA, hashB)
+	})
+
+	t.Run("Different payloads produce different hashes", func(t *testing.T) {
+		docA := NotarizationDocument{Title: "doc", Payload: json.RawMessage(`{"a":1}`)}
+		docB := NotarizationDocument{Title: "doc", Payload: json.RawMessage(`{"a":2}`)}
+
+		hashA, _, err := hashDocument(docA)
+		assert.NoError(t, err)
+		hashB, _, err := hashDocument(docB)
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, hashA, hashB)
+	})
+
+	t.Run("Non-integer payload values no longer collide after truncation", func(t *testing.T) {
+		docA := NotarizationDocument{Title: "doc", Payload: json.RawMessage(`{"price":19.99}`)}
+		docB := NotarizationDocument{Title: "doc", Payload: json.RawMessage(`{"price":19.42}`)}
+
+		hashA, _, err := hashDocument(docA)
+		assert.NoError(t, err)
+		hashB, _, err := hashDocument(docB)
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, hashA, hashB)
+	})
+
+	t.Run("Plain JSON encoding of the document is still returned for persistence", func(t *testing.T) {
+		doc := NotarizationDocument{Title: "doc", Payload: json.RawMessage(`{"a":1}`)}
+
+		_, documentBytes, err := hashDocument(doc)
+		assert.NoError(t, err)
+
+		var roundtripped NotarizationDocument
+		assert.NoError(t, json.Unmarshal(documentBytes, &roundtripped))
+		assert.Equal(t, doc, roundtripped)
+	})
+}
@@ -0,0 +1,117 @@
+// Package grpc exposes the notarizer's create/verify/health operations as
+// gRPC unary RPCs plus a streaming NotarizeStream, for service-mesh callers
+// that want to avoid HTTP/JSON overhead when notarizing at scale.
+//
+// The request/response messages and the NotarizerServer/UnimplementedNotarizerServer
+// interfaces mirror what `go generate` would produce from notarizer.proto (see
+// doc.go), but are checked in by hand in notarizer.pb.go/notarizer_grpc.pb.go
+// since protoc isn't wired into this toolchain yet; this file is the
+// hand-written server that sits on top of them.
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+
+	"google.golang.org/grpc"
+)
+
+// Backend is the subset of the notarizer package's core logic the gRPC server
+// needs. It is expressed as an interface, rather than importing the notarizer
+// package directly, so that package notarizer (which hosts this component)
+// can depend on package grpc without creating an import cycle.
+type Backend interface {
+	Create(ctx context.Context, hash string) (string, error)
+	Verify(ctx context.Context, hash string, outputIDHex string) (bool, error)
+}
+
+// Server implements the generated NotarizerServer interface by delegating to
+// a Backend, the same doCreate/doVerify core used by the Echo REST handlers.
+type Server struct {
+	UnimplementedNotarizerServer
+
+	backend    Backend
+	grpcServer *grpc.Server
+	listener   net.Listener
+}
+
+// NewServer creates a Server bound to bindAddress, serving RPCs via backend.
+// It does not start serving until Serve is called.
+func NewServer(bindAddress string, backend Backend) (*Server, error) {
+	listener, err := net.Listen("tcp", bindAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %q: %w", bindAddress, err)
+	}
+
+	s := &Server{
+		backend:    backend,
+		grpcServer: grpc.NewServer(),
+		listener:   listener,
+	}
+	RegisterNotarizerServer(s.grpcServer, s)
+
+	return s, nil
+}
+
+// Serve blocks, accepting connections until the server is stopped.
+func (s *Server) Serve() error {
+	return s.grpcServer.Serve(s.listener)
+}
+
+// Stop gracefully stops the server, waiting for in-flight RPCs to finish.
+func (s *Server) Stop() {
+	s.grpcServer.GracefulStop()
+}
+
+// Health implements the generated NotarizerServer interface.
+func (s *Server) Health(ctx context.Context, req *HealthRequest) (*HealthResponse, error) {
+	return &HealthResponse{Ok: true}, nil
+}
+
+// CreateNotarization implements the generated NotarizerServer interface.
+func (s *Server) CreateNotarization(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
+	blockID, err := s.backend.Create(ctx, req.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateResponse{BlockId: blockID}, nil
+}
+
+// VerifyNotarization implements the generated NotarizerServer interface.
+func (s *Server) VerifyNotarization(ctx context.Context, req *VerifyRequest) (*VerifyResponse, error) {
+	match, err := s.backend.Verify(ctx, req.Hash, req.OutputId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VerifyResponse{Match: match}, nil
+}
+
+// NotarizeStream implements the generated NotarizerServer interface: it
+// accepts a stream of hashes and sends back each one's block ID as soon as it
+// resolves, so a high-throughput client doesn't pay one round-trip per hash.
+func (s *Server) NotarizeStream(stream Notarizer_NotarizeStreamServer) error {
+	ctx := stream.Context()
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		blockID, err := s.backend.Create(ctx, req.Hash)
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(&CreateResponse{BlockId: blockID}); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// fakeBackend is a Backend whose Create result is driven by a test.
+type fakeBackend struct {
+	createFunc func(ctx context.Context, hash string) (string, error)
+}
+
+func (b fakeBackend) Create(ctx context.Context, hash string) (string, error) {
+	return b.createFunc(ctx, hash)
+}
+
+func (fakeBackend) Verify(context.Context, string, string) (bool, error) {
+	return false, nil
+}
+
+// fakeNotarizeStream is a Notarizer_NotarizeStreamServer driven entirely from
+// in-memory queues, so NotarizeStream can be exercised without a real gRPC
+// connection.
+type fakeNotarizeStream struct {
+	grpc.ServerStream
+
+	recvQueue []*CreateRequest
+	recvErr   error
+	sent      []*CreateResponse
+}
+
+func (s *fakeNotarizeStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeNotarizeStream) Send(resp *CreateResponse) error {
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+func (s *fakeNotarizeStream) Recv() (*CreateRequest, error) {
+	if len(s.recvQueue) == 0 {
+		if s.recvErr != nil {
+			return nil, s.recvErr
+		}
+
+		return nil, io.EOF
+	}
+
+	req := s.recvQueue[0]
+	s.recvQueue = s.recvQueue[1:]
+
+	return req, nil
+}
+
+func TestServerNotarizeStream(t *testing.T) {
+	t.Run("A client closing the stream (io.EOF) ends the RPC cleanly", func(t *testing.T) {
+		server := &Server{
+			backend: fakeBackend{createFunc: func(_ context.Context, hash string) (string, error) {
+				return "block-" + hash, nil
+			}},
+		}
+
+		stream := &fakeNotarizeStream{recvQueue: []*CreateRequest{{Hash: "a"}, {Hash: "b"}}}
+
+		err := server.NotarizeStream(stream)
+		assert.NoError(t, err)
+		assert.Equal(t, []*CreateResponse{{BlockId: "block-a"}, {BlockId: "block-b"}}, stream.sent)
+	})
+
+	t.Run("A genuine Recv error is still propagated", func(t *testing.T) {
+		server := &Server{backend: fakeBackend{createFunc: func(context.Context, string) (string, error) {
+			t.Fatal("Create should not be called once Recv has failed")
+			return "", nil
+		}}}
+
+		wantErr := errors.New("broken pipe")
+		stream := &fakeNotarizeStream{recvErr: wantErr}
+
+		err := server.NotarizeStream(stream)
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("A Create failure is propagated instead of being sent to the client", func(t *testing.T) {
+		wantErr := errors.New("backend unavailable")
+		server := &Server{backend: fakeBackend{createFunc: func(context.Context, string) (string, error) {
+			return "", wantErr
+		}}}
+
+		stream := &fakeNotarizeStream{recvQueue: []*CreateRequest{{Hash: "a"}}}
+
+		err := server.NotarizeStream(stream)
+		assert.Equal(t, wantErr, err)
+		assert.Empty(t, stream.sent)
+	})
+}
@@ -0,0 +1,245 @@
+// Hand-written, not protoc-generated: see notarizer.pb.go. Mirrors the shape
+// protoc-gen-go-grpc would emit for notarizer.proto's Notarizer service.
+
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NotarizerClient is the client API for the Notarizer service.
+type NotarizerClient interface {
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	CreateNotarization(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	VerifyNotarization(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*VerifyResponse, error)
+	NotarizeStream(ctx context.Context, opts ...grpc.CallOption) (Notarizer_NotarizeStreamClient, error)
+}
+
+type notarizerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewNotarizerClient returns a NotarizerClient backed by cc.
+func NewNotarizerClient(cc grpc.ClientConnInterface) NotarizerClient {
+	return &notarizerClient{cc}
+}
+
+func (c *notarizerClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	if err := c.cc.Invoke(ctx, "/notarizer.Notarizer/Health", in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *notarizerClient) CreateNotarization(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	if err := c.cc.Invoke(ctx, "/notarizer.Notarizer/CreateNotarization", in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *notarizerClient) VerifyNotarization(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*VerifyResponse, error) {
+	out := new(VerifyResponse)
+	if err := c.cc.Invoke(ctx, "/notarizer.Notarizer/VerifyNotarization", in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *notarizerClient) NotarizeStream(ctx context.Context, opts ...grpc.CallOption) (Notarizer_NotarizeStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Notarizer_ServiceDesc.Streams[0], "/notarizer.Notarizer/NotarizeStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &notarizerNotarizeStreamClient{stream}, nil
+}
+
+// Notarizer_NotarizeStreamClient is the client-side handle for the
+// bidirectional NotarizeStream RPC. //nolint:revive,stylecheck
+type Notarizer_NotarizeStreamClient interface { //nolint:revive,stylecheck
+	Send(*CreateRequest) error
+	Recv() (*CreateResponse, error)
+	grpc.ClientStream
+}
+
+type notarizerNotarizeStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *notarizerNotarizeStreamClient) Send(m *CreateRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *notarizerNotarizeStreamClient) Recv() (*CreateResponse, error) {
+	m := new(CreateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// NotarizerServer is the server API for the Notarizer service. All
+// implementations must embed UnimplementedNotarizerServer for forward
+// compatibility.
+type NotarizerServer interface {
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+	CreateNotarization(context.Context, *CreateRequest) (*CreateResponse, error)
+	VerifyNotarization(context.Context, *VerifyRequest) (*VerifyResponse, error)
+	NotarizeStream(Notarizer_NotarizeStreamServer) error
+	mustEmbedUnimplementedNotarizerServer()
+}
+
+// UnimplementedNotarizerServer must be embedded to have forward compatible
+// implementations.
+type UnimplementedNotarizerServer struct{}
+
+func (UnimplementedNotarizerServer) Health(context.Context, *HealthRequest) (*HealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
+
+func (UnimplementedNotarizerServer) CreateNotarization(context.Context, *CreateRequest) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateNotarization not implemented")
+}
+
+func (UnimplementedNotarizerServer) VerifyNotarization(context.Context, *VerifyRequest) (*VerifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyNotarization not implemented")
+}
+
+func (UnimplementedNotarizerServer) NotarizeStream(Notarizer_NotarizeStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method NotarizeStream not implemented")
+}
+
+func (UnimplementedNotarizerServer) mustEmbedUnimplementedNotarizerServer() {}
+
+// RegisterNotarizerServer registers srv with s.
+func RegisterNotarizerServer(s grpc.ServiceRegistrar, srv NotarizerServer) {
+	s.RegisterService(&Notarizer_ServiceDesc, srv)
+}
+
+func _Notarizer_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotarizerServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notarizer.Notarizer/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotarizerServer).Health(ctx, req.(*HealthRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notarizer_CreateNotarization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotarizerServer).CreateNotarization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notarizer.Notarizer/CreateNotarization",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotarizerServer).CreateNotarization(ctx, req.(*CreateRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notarizer_VerifyNotarization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotarizerServer).VerifyNotarization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notarizer.Notarizer/VerifyNotarization",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotarizerServer).VerifyNotarization(ctx, req.(*VerifyRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notarizer_NotarizeStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NotarizerServer).NotarizeStream(&notarizerNotarizeStreamServer{stream})
+}
+
+// Notarizer_NotarizeStreamServer is the server-side handle for the
+// bidirectional NotarizeStream RPC. //nolint:revive,stylecheck
+type Notarizer_NotarizeStreamServer interface { //nolint:revive,stylecheck
+	Send(*CreateResponse) error
+	Recv() (*CreateRequest, error)
+	grpc.ServerStream
+}
+
+type notarizerNotarizeStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *notarizerNotarizeStreamServer) Send(m *CreateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *notarizerNotarizeStreamServer) Recv() (*CreateRequest, error) {
+	m := new(CreateRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Notarizer_ServiceDesc is the grpc.ServiceDesc for the Notarizer service.
+// It's only intended for direct use with grpc.RegisterService, and not to be
+// introspected or modified (even as a copy). //nolint:revive,stylecheck
+var Notarizer_ServiceDesc = grpc.ServiceDesc{ //nolint:revive,stylecheck
+	ServiceName: "notarizer.Notarizer",
+	HandlerType: (*NotarizerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Health",
+			Handler:    _Notarizer_Health_Handler,
+		},
+		{
+			MethodName: "CreateNotarization",
+			Handler:    _Notarizer_CreateNotarization_Handler,
+		},
+		{
+			MethodName: "VerifyNotarization",
+			Handler:    _Notarizer_VerifyNotarization_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "NotarizeStream",
+			Handler:       _Notarizer_NotarizeStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "notarizer.proto",
+}
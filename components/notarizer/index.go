@@ -0,0 +1,212 @@
+package notarizer
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/serializer/v2"
+	inx "github.com/iotaledger/inx/go"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/labstack/echo/v4"
+
+	"github.com/adrian-grassl/inx-notarizer/pkg/index"
+)
+
+var (
+	notarizationIndexOnce sync.Once
+	notarizationIndex     *index.BoltIndex
+	notarizationIndexErr  error
+)
+
+// getNotarizationIndex lazily opens the local index at the configured
+// IndexPath.
+func getNotarizationIndex() (*index.BoltIndex, error) {
+	notarizationIndexOnce.Do(func() {
+		notarizationIndex, notarizationIndexErr = index.NewBoltIndex(ParamsRestAPI.IndexPath)
+	})
+
+	return notarizationIndex, notarizationIndexErr
+}
+
+// recordAnchor records a successful anchor of hash in the local index so it
+// can later be looked up without remembering its output ID. The anchored
+// output is always the first output of txPayload, the same assumption
+// flushBatch relies on when it derives a flushed batch's OutputID. Errors are
+// logged rather than returned, since the on-chain anchor has already
+// succeeded by the time this is called and failing the request over it would
+// be misleading.
+func recordAnchor(hash string, txPayload *iotago.Transaction, hexBlockID string, batchID *string, leafIndex *int) {
+	idx, err := getNotarizationIndex()
+	if err != nil {
+		Logger.Errorf("Error opening notarization index: %v", err)
+		return
+	}
+
+	transactionID, err := txPayload.ID()
+	if err != nil {
+		Logger.Errorf("Error deriving transaction ID for index: %v", err)
+		return
+	}
+	outputID := iotago.OutputIDFromTransactionIDAndIndex(transactionID, 0)
+
+	record := index.AnchorRecord{
+		OutputID:  outputID.ToHex(),
+		BlockID:   hexBlockID,
+		Timestamp: time.Now().UTC(),
+	}
+	if batchID != nil {
+		record.BatchID = *batchID
+	}
+	record.LeafIndex = leafIndex
+
+	if err := idx.RecordAnchor(hash, record); err != nil {
+		Logger.Errorf("Error recording anchor for hash %s: %v", hash, err)
+	}
+}
+
+// lookupNotarization returns every known anchor record for a hash from the
+// local index.
+func lookupNotarization(c echo.Context) error {
+	hash := c.Param(ParameterHash)
+
+	idx, err := getNotarizationIndex()
+	if err != nil {
+		Logger.Errorf("Error opening notarization index: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error opening notarization index")
+	}
+
+	records, err := idx.Lookup(hash)
+	if err != nil {
+		Logger.Errorf("Error looking up hash %s: %v", hash, err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error looking up hash")
+	}
+	if len(records) == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "No anchor recorded for hash")
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"hash": hash, "anchors": records})
+}
+
+// historyNotarization returns anchors recorded within the [from, to] time
+// range, oldest first, capped at limit entries.
+func historyNotarization(c echo.Context) error {
+	from, err := parseHistoryTime(c.QueryParam("from"), time.Unix(0, 0).UTC())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid 'from' parameter")
+	}
+
+	to, err := parseHistoryTime(c.QueryParam("to"), time.Now().UTC())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid 'to' parameter")
+	}
+
+	limit := 0
+	if rawLimit := c.QueryParam("limit"); rawLimit != "" {
+		limit, err = strconv.Atoi(rawLimit)
+		if err != nil || limit < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid 'limit' parameter")
+		}
+	}
+
+	idx, err := getNotarizationIndex()
+	if err != nil {
+		Logger.Errorf("Error opening notarization index: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error opening notarization index")
+	}
+
+	entries, err := idx.History(from, to, limit)
+	if err != nil {
+		Logger.Errorf("Error querying notarization history: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error querying notarization history")
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"entries": entries})
+}
+
+// parseHistoryTime parses an RFC3339 timestamp, returning fallback if raw is empty.
+func parseHistoryTime(raw string, fallback time.Time) (time.Time, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+
+	return time.Parse(time.RFC3339, raw)
+}
+
+// runIndexBackfill subscribes to NodeBridge ledger updates and indexes every
+// created BasicOutput owned by the notarizer's own address that carries a
+// MetadataFeature, so notarizations submitted by other clients on the same
+// address (e.g. a redundant notarizer instance) end up in the local index
+// too. It is meant to run as a BackgroundWorker tied to the Component's
+// Daemon lifecycle, and only runs when IndexBackfillEnabled is set.
+func runIndexBackfill(ctx context.Context) {
+	protoParas := deps.NodeBridge.ProtocolParameters()
+
+	configuredSigner, err := configuredSigner()
+	if err != nil {
+		Logger.Errorf("Error resolving signer backend for index backfill: %v", err)
+		return
+	}
+
+	walletObject, err := prepWallet(protoParas, configuredSigner)
+	if err != nil {
+		Logger.Errorf("Error preparing wallet for index backfill: %v", err)
+		return
+	}
+
+	err = deps.NodeBridge.ListenToLedgerUpdates(ctx, 0, 0, func(update *nodebridge.LedgerUpdate) error {
+		for _, created := range update.Created {
+			backfillCreatedOutput(protoParas, walletObject.Ed25519Address, created)
+		}
+
+		return nil
+	})
+	if err != nil && ctx.Err() == nil {
+		Logger.Errorf("Error listening to ledger updates for index backfill: %v", err)
+	}
+}
+
+// backfillCreatedOutput indexes created if it is a BasicOutput addressed to
+// ownAddress and carrying a MetadataFeature, treating the feature's data as
+// the notarized hash.
+func backfillCreatedOutput(protoParas *iotago.ProtocolParameters, ownAddress *iotago.Ed25519Address, created *inx.LedgerOutput) {
+	output, err := created.UnwrapOutput(serializer.DeSeriModeNoValidation, protoParas)
+	if err != nil {
+		Logger.Errorf("Error unwrapping ledger output for index backfill: %v", err)
+		return
+	}
+
+	basicOutput, ok := output.(*iotago.BasicOutput)
+	if !ok {
+		return
+	}
+
+	addressCondition := basicOutput.UnlockConditionSet().Address()
+	if addressCondition == nil || !addressCondition.Address.Equal(ownAddress) {
+		return
+	}
+
+	metadataFeature := basicOutput.FeatureSet().MetadataFeature()
+	if metadataFeature == nil {
+		return
+	}
+
+	idx, err := getNotarizationIndex()
+	if err != nil {
+		Logger.Errorf("Error opening notarization index: %v", err)
+		return
+	}
+
+	record := index.AnchorRecord{
+		OutputID:  created.UnwrapOutputID().ToHex(),
+		BlockID:   created.UnwrapBlockID().ToHex(),
+		Timestamp: time.Now().UTC(),
+	}
+
+	if err := idx.RecordAnchor(string(metadataFeature.Data), record); err != nil {
+		Logger.Errorf("Error backfilling anchor: %v", err)
+	}
+}
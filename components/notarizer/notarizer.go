@@ -4,17 +4,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/adrian-grassl/inx-notarizer/pkg/hdwallet"
+	"github.com/adrian-grassl/inx-notarizer/pkg/signer"
 	"github.com/iotaledger/hive.go/logger"
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/iota.go/v3/builder"
 	"github.com/iotaledger/iota.go/v3/nodeclient"
 	"github.com/labstack/echo/v4"
+
+	kms "cloud.google.com/go/kms/apiv1"
+	vaultapi "github.com/hashicorp/vault/api"
 )
 
 // Global variable for the plugin's logger
@@ -65,87 +70,106 @@ func createNotarization(c echo.Context) error {
 	hash := c.Param("hash")
 	Logger.Debugf("Notarization Hash: %s", hash)
 
+	hexBlockId, err := doCreate(c.Request().Context(), hash)
+	if err != nil {
+		Logger.Errorf("Error creating notarization: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	Logger.Infof("Block attached with ID: %v", hexBlockId)
+
+	// Return success response with block ID.
+	return c.JSON(http.StatusOK, map[string]string{"blockId": hexBlockId})
+}
+
+func verifyNotarization(c echo.Context) error {
+	type body struct {
+		Hash     string `json:"hash"`
+		OutputID string `json:"outputID"`
+	}
+
+	var requestBody body
+
+	defer c.Request().Body.Close()
+
+	err := json.NewDecoder(c.Request().Body).Decode(&requestBody)
+	if err != nil {
+		Logger.Errorf("Error decoding request body: %v", requestBody)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error decoding request body")
+	}
+
+	match, err := doVerify(c.Request().Context(), requestBody.Hash, requestBody.OutputID)
+	if err != nil {
+		Logger.Errorf("Error verifying notarization: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]bool{"match": match})
+}
+
+// doCreate anchors hash on-chain and returns the hex-encoded ID of the block
+// it was attached in. It contains the core logic of createNotarization,
+// extracted so that other API surfaces (e.g. the gRPC server) can share it
+// without depending on echo.Context.
+func doCreate(ctx context.Context, hash string) (string, error) {
 	protoParas := deps.NodeBridge.ProtocolParameters()
 	Logger.Debugf("Protocol Parameters: %v, %T", protoParas, protoParas)
 
-	// Load mnemonic from .env
-	mnemonic, err := loadEnvVariable("MNEMONIC")
+	// Resolve the configured signer backend and derive the notarizing wallet.
+	configuredSigner, err := configuredSigner()
 	if err != nil {
-		Logger.Errorf("Error loading mnemonic: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Error loading mnemonic")
+		return "", fmt.Errorf("error resolving signer backend: %w", err)
 	}
-	Logger.Debug("Mnemonic loaded successfully")
 
-	// Prepare wallet address and signer
-	walletObject, err := prepWallet(protoParas, mnemonic)
+	walletObject, err := prepWallet(protoParas, configuredSigner)
 	if err != nil {
-		Logger.Errorf("Error preparing wallet: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Error preparing wallet")
+		return "", fmt.Errorf("error preparing wallet: %w", err)
 	}
 
 	// Fetch outputs for address
 	indexerResultSet, err := fetchOutputsByAddress(walletObject.Bech32Address)
 	if err != nil {
-		Logger.Errorf("Error fetching outputs: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Error fetching outputs")
+		return "", fmt.Errorf("error fetching outputs: %w", err)
 	}
 
 	// Filter outputs for their eligibility to become input to the tx.
 	unspentOutputs, err := filterOutputs(indexerResultSet)
 	if err != nil {
-		Logger.Errorf("Error filtering outputs: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Error filtering outputs")
+		return "", fmt.Errorf("error filtering outputs: %w", err)
 	}
 
 	// Prepare transaction payload including the notarization hash.
 	txPayload, err := prepTxPayload(protoParas, unspentOutputs, walletObject.Ed25519Address, walletObject.AddressSigner, hash)
 	if err != nil {
-		Logger.Errorf("Error preparing transaction payload: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Error preparing transaction payload")
+		return "", fmt.Errorf("error preparing transaction payload: %w", err)
 	}
 
 	// Prepare and send the block with the notarization transaction.
-	hexBlockId, err := prepAndSendBlock(c, protoParas, txPayload)
+	hexBlockId, err := prepAndSendBlockCtx(ctx, protoParas, txPayload)
 	if err != nil {
-		Logger.Errorf("Error preparing and sending block: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Error preparing and sending block")
-	}
-	Logger.Infof("Block attached with ID: %v", hexBlockId)
-
-	// Return success response with block ID.
-	return c.JSON(http.StatusOK, map[string]string{"blockId": hexBlockId})
-}
-
-func verifyNotarization(c echo.Context) error {
-	type body struct {
-		Hash     string `json:"hash"`
-		OutputID string `json:"outputID"`
+		return "", fmt.Errorf("error preparing and sending block: %w", err)
 	}
 
-	var requestBody body
-
-	defer c.Request().Body.Close()
+	recordAnchor(hash, txPayload, hexBlockId, nil, nil)
 
-	err := json.NewDecoder(c.Request().Body).Decode(&requestBody)
-	if err != nil {
-		Logger.Errorf("Error decoding request body: %v", requestBody)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Error decoding request body")
-	}
+	return hexBlockId, nil
+}
 
-	outputID, err := iotago.OutputIDFromHex(requestBody.OutputID)
+// doVerify reports whether outputIDHex's MetadataFeature matches hash. It
+// contains the core logic of verifyNotarization, extracted so that other API
+// surfaces (e.g. the gRPC server) can share it without depending on
+// echo.Context.
+func doVerify(ctx context.Context, hash string, outputIDHex string) (bool, error) {
+	outputID, err := iotago.OutputIDFromHex(outputIDHex)
 	if err != nil {
-		Logger.Errorf("Error converting outputID string: %v", requestBody.OutputID)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Error converting outputID string")
+		return false, fmt.Errorf("error converting outputID string: %w", err)
 	}
 
-	ctx := c.Request().Context()
-
 	inxNodeClient := deps.INXNodeClient
 
 	output, err := inxNodeClient.OutputByID(ctx, outputID)
 	if err != nil {
 		Logger.Debug("No output found with passed outputID.")
-		return c.JSON(http.StatusOK, map[string]bool{"match": false})
+		return false, nil
 	}
 	Logger.Debugf("Output: %v, %T", output, output)
 
@@ -153,40 +177,32 @@ func verifyNotarization(c echo.Context) error {
 	basicOutput, ok := output.(*iotago.BasicOutput)
 	if !ok {
 		// Handle the case where the output isn't a *iotago.BasicOutput
-		Logger.Error("Output is not of type *iotago.BasicOutput")
-		return echo.NewHTTPError(http.StatusInternalServerError, "Unexpected output type")
+		return false, fmt.Errorf("unexpected output type %T", output)
 	}
 	Logger.Debugf("basicOutput: %v, %T", basicOutput, basicOutput)
 
-	// JSON
-	basicOutputJSON, err := basicOutput.MarshalJSON()
-	if err != nil {
-		Logger.Errorf("Error marshalling basic output to JSON: %v", basicOutput)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Error marshalling basic output to JSON")
-	}
-	Logger.Debugf("basicOutputJSON: %v, %T", string(basicOutputJSON), string(basicOutputJSON))
-
-	// Iterate over features to find MetadataFeature
-	for _, feature := range basicOutput.Features {
-		featureJSON, err := feature.MarshalJSON()
-		if err != nil {
-			Logger.Errorf("Error marshalling feature data to JSON: %v", basicOutput)
-			return echo.NewHTTPError(http.StatusInternalServerError, "Error marshalling feature data to JSON")
-		}
-		Logger.Debugf("featureJSON: %v, %T", string(featureJSON), string(featureJSON))
-
-		metadataFeature, ok := feature.(*iotago.MetadataFeature)
-		if ok {
-			dataUtf8 := string(metadataFeature.Data)
-			if dataUtf8 == requestBody.Hash {
-				Logger.Debugf("Matching hash found: %v", dataUtf8)
-				return c.JSON(http.StatusOK, map[string]bool{"match": true})
-			}
-		}
+	metadataFeature := basicOutput.FeatureSet().MetadataFeature()
+	if metadataFeature != nil && string(metadataFeature.Data) == hash {
+		Logger.Debugf("Matching hash found: %v", hash)
+		return true, nil
 	}
 
 	Logger.Debug("No metadata feature found in output.")
-	return c.JSON(http.StatusOK, map[string]bool{"match": false})
+	return false, nil
+}
+
+// Create anchors hash on-chain and returns the hex-encoded ID of the block it
+// was attached in. It is the exported entry point for API surfaces other than
+// the Echo REST handlers, such as the gRPC server.
+func Create(ctx context.Context, hash string) (string, error) {
+	return doCreate(ctx, hash)
+}
+
+// Verify reports whether outputIDHex's MetadataFeature matches hash. It is the
+// exported entry point for API surfaces other than the Echo REST handlers,
+// such as the gRPC server.
+func Verify(ctx context.Context, hash string, outputIDHex string) (bool, error) {
+	return doVerify(ctx, hash, outputIDHex)
 }
 
 // loadEnvVariable loads mnemonic phrases from the given environment variable.
@@ -204,16 +220,10 @@ func loadEnvVariable(name string) ([]string, error) {
 	return phrases, nil
 }
 
-// prepWallet prepares the wallet for transactions by loading the mnemonic and creating a wallet object.
-func prepWallet(protoParas *iotago.ProtocolParameters, mnemonic []string) (*WalletObject, error) {
-
-	wallet, err := hdwallet.NewHDWallet(protoParas, mnemonic, "", 0, false)
-	if err != nil {
-		return nil, fmt.Errorf("creating wallet failed, err: %s", err)
-	}
-	Logger.Debugf("Wallet created successfully")
-
-	address, signer, err := wallet.Ed25519AddressAndSigner(0)
+// prepWallet prepares the wallet for transactions by deriving the notarizing
+// address and signer from the configured signer backend.
+func prepWallet(protoParas *iotago.ProtocolParameters, s signer.Signer) (*WalletObject, error) {
+	address, addressSigner, err := s.Ed25519AddressAndSigner(protoParas)
 	if err != nil {
 		return nil, fmt.Errorf("deriving ed25519 address and signer failed, err: %s", err)
 	}
@@ -225,10 +235,96 @@ func prepWallet(protoParas *iotago.ProtocolParameters, mnemonic []string) (*Wall
 	return &WalletObject{
 		Bech32Address:  bech32,
 		Ed25519Address: address,
-		AddressSigner:  signer,
+		AddressSigner:  addressSigner,
 	}, nil
 }
 
+var (
+	configuredSignerOnce sync.Once
+	cachedSigner         signer.Signer
+	configuredSignerErr  error
+)
+
+// configuredSigner lazily builds the signer.Signer backend selected by
+// ParamsRestAPI.Signer.Backend and caches it for the lifetime of the
+// process. The remote and KMS backends each dial out over gRPC; building a
+// fresh one on every call (doCreate runs on every notarization request)
+// leaked a connection per request, so buildSigner only ever runs once here.
+// Close tears the cached backend's connection back down on shutdown.
+func configuredSigner() (signer.Signer, error) {
+	configuredSignerOnce.Do(func() {
+		cachedSigner, configuredSignerErr = buildSigner()
+	})
+
+	return cachedSigner, configuredSignerErr
+}
+
+// closeSigner closes the cached signer backend's underlying connection, if
+// it holds one. The remote and KMS backends do; mnemonic and vault don't, so
+// they're left untouched.
+func closeSigner() error {
+	if closer, ok := cachedSigner.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// buildSigner builds the signer.Signer backend selected by
+// ParamsRestAPI.Signer.Backend. Mnemonics only ever leave a .env file when
+// the "mnemonic" backend is selected; every other backend keeps the signing
+// key outside the notarizer process entirely.
+func buildSigner() (signer.Signer, error) {
+	switch ParamsRestAPI.Signer.Backend {
+	case "", "mnemonic":
+		mnemonic, err := loadEnvVariable("MNEMONIC")
+		if err != nil {
+			return nil, fmt.Errorf("error loading mnemonic: %w", err)
+		}
+
+		return signer.NewMnemonicSigner(mnemonic), nil
+
+	case "vault":
+		cfg := ParamsRestAPI.Signer.Vault
+
+		client, err := vaultapi.NewClient(&vaultapi.Config{Address: cfg.Address})
+		if err != nil {
+			return nil, fmt.Errorf("creating vault client failed, err: %w", err)
+		}
+		client.SetToken(cfg.Token)
+
+		return signer.NewVaultSigner(client, cfg.KeyName), nil
+
+	case "kms":
+		cfg := ParamsRestAPI.Signer.KMS
+
+		client, err := kms.NewKeyManagementClient(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("creating KMS client failed, err: %w", err)
+		}
+
+		return signer.NewKMSSigner(client, cfg.KeyVersionName), nil
+
+	case "remote":
+		cfg := ParamsRestAPI.Signer.Remote
+
+		creds, err := signer.TLSCredentials(signer.RemoteSignerTLSConfig{
+			Enabled:        cfg.TLSEnabled,
+			CACertFile:     cfg.TLSCACertFile,
+			ClientCertFile: cfg.TLSClientCertFile,
+			ClientKeyFile:  cfg.TLSClientKeyFile,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("configuring remote signer TLS failed, err: %w", err)
+		}
+
+		return signer.NewRemoteSigner(cfg.Address, creds)
+
+	default:
+		return nil, fmt.Errorf("unknown signer backend %q", ParamsRestAPI.Signer.Backend)
+	}
+}
+
 // fetchOutputsByAddress fetches the unspent outputs associated with a certain address.
 func fetchOutputsByAddress(bech32 string) ([]UTXOOutput, error) {
 	ctxIndexer, cancelIndexer := context.WithTimeout(context.Background(), indexerPluginAvailableTimeout)
@@ -345,8 +441,12 @@ func prepTxPayload(protoParas *iotago.ProtocolParameters, unspentOutputs []Basic
 
 // prepAndSendBlock prepares and submits a block with the transaction payload.
 func prepAndSendBlock(c echo.Context, protoParas *iotago.ProtocolParameters, txPayload *iotago.Transaction) (string, error) {
-	ctx := c.Request().Context()
+	return prepAndSendBlockCtx(c.Request().Context(), protoParas, txPayload)
+}
 
+// prepAndSendBlockCtx is the context-based core of prepAndSendBlock, usable by
+// callers that don't have an echo.Context, such as the batch flusher.
+func prepAndSendBlockCtx(ctx context.Context, protoParas *iotago.ProtocolParameters, txPayload *iotago.Transaction) (string, error) {
 	transactionID, err := txPayload.ID()
 	if err != nil {
 		return "", fmt.Errorf("failed to get transaction ID: %v", err)
@@ -1,6 +1,7 @@
 package notarizer
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/adrian-grassl/inx-notarizer/pkg/common"
+	"github.com/adrian-grassl/inx-notarizer/pkg/signer"
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/stretchr/testify/assert"
 )
@@ -28,7 +30,7 @@ func TestCreateNotarization(t *testing.T) {
 		requestURL := fmt.Sprintf("%s/notarize/%s", pluginURL, hashValue)
 
 		// Execution
-		httpResponse, err := common.PostRequest(requestURL, "", nil)
+		httpResponse, err := common.PostRequest(context.Background(), requestURL, nil)
 		assert.NoError(t, err)
 
 		responseBody, err := io.ReadAll(httpResponse.Body)
@@ -99,7 +101,7 @@ func TestPrepWallet(t *testing.T) {
 		expectedValue := "tst1qzguhtxyuhgp4aklfkyd5ek3wtnta649pqvccrep95kesjf5kxuzvexrv6n"
 
 		// Execute
-		walletObject, err := prepWallet(protoParas, mnemonic)
+		walletObject, err := prepWallet(protoParas, signer.NewMnemonicSigner(mnemonic))
 		t.Logf("walletObject: %v", walletObject)
 
 		// Assert
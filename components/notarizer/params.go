@@ -1,6 +1,8 @@
 package notarizer
 
 import (
+	"time"
+
 	"github.com/iotaledger/hive.go/app"
 )
 
@@ -17,13 +19,124 @@ type ParametersRestAPI struct {
 
 	// MetadataCacheSize defines the size of the cache for each IRC standard.
 	MetadataCacheSize int `default:"1000" usage:"defines the size of the cache for each IRC standard"`
+
+	// DocumentStore defines which backend full notarization documents are
+	// persisted in, keyed by their on-chain hash.
+	DocumentStore struct {
+		// Backend selects the document store implementation: "file" or "bolt".
+		Backend string `default:"file" usage:"the document store backend to use (file or bolt)"`
+
+		File struct {
+			// Directory is the directory in which documents are stored, one
+			// file per hash.
+			Directory string `default:"notarizer_documents" usage:"the directory in which full notarization documents are stored"`
+		}
+
+		Bolt struct {
+			// Path is the embedded database file documents are stored in.
+			Path string `default:"notarizer_documents.db" usage:"the embedded database file full notarization documents are stored in"`
+		}
+	}
+
+	// BatchMaxSize defines how many hashes are buffered into a batch before it
+	// is flushed into a single on-chain output.
+	BatchMaxSize int `default:"128" usage:"the maximum number of hashes buffered into a batch before it is flushed"`
+
+	// BatchMaxWait defines the maximum time a batch is left open before it is
+	// flushed, even if BatchMaxSize has not been reached.
+	BatchMaxWait time.Duration `default:"10s" usage:"the maximum time a batch is left open before it is flushed"`
+
+	// BatchStore defines which backend flushed and pending batches are
+	// persisted in, so Merkle inclusion proofs remain servable and buffered
+	// hashes are not lost across a restart.
+	BatchStore struct {
+		// Backend selects the batch store implementation: "file" or "bolt".
+		Backend string `default:"file" usage:"the batch store backend to use (file or bolt)"`
+
+		File struct {
+			// Directory is the directory in which batches are stored, one
+			// file per batch.
+			Directory string `default:"notarizer_batches" usage:"the directory in which flushed and pending batches are stored"`
+		}
+
+		Bolt struct {
+			// Path is the embedded database file batches are stored in.
+			Path string `default:"notarizer_batches.db" usage:"the embedded database file flushed and pending batches are stored in"`
+		}
+	}
+
+	// IndexPath defines the file in which the local per-hash notarization
+	// index is stored.
+	IndexPath string `default:"notarizer_index.db" usage:"the file in which the local per-hash notarization index is stored"`
+
+	// IndexBackfillEnabled defines whether the index is additionally
+	// backfilled from NodeBridge ledger updates, so notarizations submitted
+	// by other clients on the same address are indexed too.
+	IndexBackfillEnabled bool `default:"false" usage:"whether the local index is backfilled from NodeBridge ledger updates"`
+
+	// Signer defines which backend holds the Ed25519 key the notarizer signs
+	// with.
+	Signer struct {
+		// Backend selects the signer implementation: "mnemonic", "vault", "kms" or "remote".
+		Backend string `default:"mnemonic" usage:"the signer backend to use (mnemonic, vault, kms or remote)"`
+
+		Vault struct {
+			// Address is the address of the Vault server.
+			Address string `default:"" usage:"the address of the Vault server"`
+			// Token is used to authenticate against Vault.
+			Token string `default:"" usage:"the token used to authenticate against Vault"`
+			// KeyName is the name of the transit key used to sign.
+			KeyName string `default:"" usage:"the name of the Vault transit key used to sign"`
+		}
+
+		KMS struct {
+			// KeyVersionName is the full resource name of the Cloud KMS
+			// CryptoKeyVersion used to sign, e.g.
+			// "projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1".
+			KeyVersionName string `default:"" usage:"the resource name of the Cloud KMS key version used to sign"`
+		}
+
+		Remote struct {
+			// Address is the address of the remote signer's gRPC endpoint.
+			Address string `default:"" usage:"the address of the remote signer's gRPC endpoint"`
+
+			// TLSEnabled defines whether the connection to the remote signer is
+			// secured with TLS. Only leave this false if the remote signer is
+			// reachable exclusively over a trusted local/private network.
+			TLSEnabled bool `default:"false" usage:"whether the connection to the remote signer is secured with TLS"`
+
+			// TLSCACertFile is an optional path to a PEM CA certificate used to
+			// verify the remote signer's certificate, instead of the system
+			// certificate pool.
+			TLSCACertFile string `default:"" usage:"path to a PEM CA certificate used to verify the remote signer's certificate (optional, defaults to the system pool)"`
+
+			// TLSClientCertFile and TLSClientKeyFile are an optional PEM client
+			// certificate and key pair presented to the remote signer for mutual
+			// TLS.
+			TLSClientCertFile string `default:"" usage:"path to a PEM client certificate presented to the remote signer for mutual TLS (optional)"`
+			TLSClientKeyFile  string `default:"" usage:"path to the PEM key for TLSClientCertFile (optional)"`
+		}
+	}
+}
+
+// ParametersGRPC contains the definition of the parameters used by the
+// inx-notarizer gRPC server.
+type ParametersGRPC struct {
+	// Enabled defines whether the gRPC server is started alongside the REST API.
+	Enabled bool `default:"false" usage:"whether the inx-notarizer gRPC server is enabled"`
+
+	// BindAddress defines the bind address on which the inx-notarizer gRPC server listens.
+	BindAddress string `default:"localhost:9688" usage:"the bind address on which the inx-notarizer gRPC server listens"`
 }
 
 var ParamsRestAPI = &ParametersRestAPI{}
 
+var ParamsGRPC = &ParametersGRPC{}
+
 var params = &app.ComponentParams{
 	Params: map[string]any{
 		"restAPI": ParamsRestAPI,
+		"grpc":    ParamsGRPC,
 	},
 	Masked: nil,
 }
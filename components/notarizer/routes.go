@@ -15,10 +15,60 @@ const (
 	RouteHealth             = "/health"
 	RouteCreateNotarization = "/create/:" + ParameterHash
 	RouteVerifyNotarization = "/verify"
+
+	// RouteCreateTypedNotarization accepts a typed JSON document plus its type
+	// descriptor and notarizes the deterministically derived hash.
+	RouteCreateTypedNotarization = "/create/typed"
+
+	// RouteVerifyTypedNotarization re-derives a typed hash from the raw document
+	// and type descriptor and compares it against an on-chain output.
+	RouteVerifyTypedNotarization = "/verify/typed"
+
+	// RouteCreateDocumentNotarization accepts a full notarization document,
+	// persists it in the document store and notarizes its hash.
+	RouteCreateDocumentNotarization = "/create/document"
+
+	// RouteGetDocumentNotarization returns the full notarization document
+	// previously persisted under the given hash.
+	RouteGetDocumentNotarization = "/document/:" + ParameterHash
+
+	// RouteVerifyDocumentNotarization re-derives a document's hash and compares
+	// it against an on-chain output.
+	RouteVerifyDocumentNotarization = "/verify/document"
+
+	// RouteCreateBatchedNotarization buffers a hash into the currently open
+	// batch and returns its batch ID and leaf index.
+	RouteCreateBatchedNotarization = "/create/batched/:" + ParameterHash
+
+	// RouteBatchProof returns the Merkle inclusion proof for a leaf of an
+	// already-flushed batch.
+	RouteBatchProof = "/proof/:" + ParameterBatchID + "/:" + ParameterLeafIndex
+
+	// RouteVerifyBatchedNotarization recomputes a batch's Merkle root from a
+	// hash and its inclusion proof and compares it against an on-chain output.
+	RouteVerifyBatchedNotarization = "/verify/batched"
+
+	// RouteLookupNotarization returns every known anchor record for a hash
+	// from the local index, so a caller no longer needs to remember its
+	// output ID to prove the hash was notarized.
+	RouteLookupNotarization = "/lookup/:" + ParameterHash
+
+	// RouteHistory returns anchors recorded within a time range.
+	RouteHistory = "/history"
 )
 
 func setupRoutes(routeGroup *echo.Group) {
 	routeGroup.GET(RouteHealth, getHealth)
 	routeGroup.POST(RouteCreateNotarization, createNotarization)
 	routeGroup.POST(RouteVerifyNotarization, verifyNotarization)
+	routeGroup.POST(RouteCreateTypedNotarization, createTypedNotarization)
+	routeGroup.POST(RouteVerifyTypedNotarization, verifyTypedNotarization)
+	routeGroup.POST(RouteCreateDocumentNotarization, createDocumentNotarization)
+	routeGroup.GET(RouteGetDocumentNotarization, getDocumentNotarization)
+	routeGroup.POST(RouteVerifyDocumentNotarization, verifyDocumentNotarization)
+	routeGroup.POST(RouteCreateBatchedNotarization, createBatchedNotarization)
+	routeGroup.GET(RouteBatchProof, getBatchProof)
+	routeGroup.POST(RouteVerifyBatchedNotarization, verifyBatchedNotarization)
+	routeGroup.GET(RouteLookupNotarization, lookupNotarization)
+	routeGroup.GET(RouteHistory, historyNotarization)
 }
@@ -0,0 +1,433 @@
+package notarizer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/crypto/sha3"
+)
+
+// domainSeparatorPrefix identifies the inx-notarizer typed-hashing scheme so that
+// hashes produced here can never collide with hashes produced by an unrelated
+// EIP-712 domain.
+const domainSeparatorPrefix = "INX-NOTARIZER-TYPED"
+
+// TypeDescriptor describes the shape of a typed message, mapping each field name
+// to its primitive type (e.g. "string", "uint64", "bytes"), similar to the
+// "types" section of an EIP-712 typed data payload.
+type TypeDescriptor map[string]string
+
+// typedNotarizationRequest is the payload accepted by RouteCreateTypedNotarization.
+type typedNotarizationRequest struct {
+	AppName string          `json:"appName"`
+	Types   TypeDescriptor  `json:"types"`
+	Message json.RawMessage `json:"message"`
+}
+
+// typedVerificationRequest is the payload accepted by RouteVerifyTypedNotarization.
+type typedVerificationRequest struct {
+	AppName  string          `json:"appName"`
+	Types    TypeDescriptor  `json:"types"`
+	Message  json.RawMessage `json:"message"`
+	OutputID string          `json:"outputID"`
+}
+
+// createTypedNotarization deterministically hashes a typed JSON document and
+// anchors the resulting hash the same way createNotarization anchors a
+// caller-supplied hash string.
+func createTypedNotarization(c echo.Context) error {
+	var requestBody typedNotarizationRequest
+
+	defer c.Request().Body.Close()
+
+	if err := json.NewDecoder(c.Request().Body).Decode(&requestBody); err != nil {
+		Logger.Errorf("Error decoding typed notarization request: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error decoding request body")
+	}
+
+	protoParas := deps.NodeBridge.ProtocolParameters()
+
+	typedHash, err := hashTypedMessage(protoParas.NetworkID(), requestBody.AppName, requestBody.Types, requestBody.Message)
+	if err != nil {
+		Logger.Errorf("Error hashing typed message: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Error hashing typed message")
+	}
+	hexTypedHash := hex.EncodeToString(typedHash[:])
+	Logger.Debugf("Typed notarization hash: %s", hexTypedHash)
+
+	configuredSigner, err := configuredSigner()
+	if err != nil {
+		Logger.Errorf("Error resolving signer backend: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error resolving signer backend")
+	}
+
+	walletObject, err := prepWallet(protoParas, configuredSigner)
+	if err != nil {
+		Logger.Errorf("Error preparing wallet: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error preparing wallet")
+	}
+
+	indexerResultSet, err := fetchOutputsByAddress(walletObject.Bech32Address)
+	if err != nil {
+		Logger.Errorf("Error fetching outputs: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error fetching outputs")
+	}
+
+	unspentOutputs, err := filterOutputs(indexerResultSet)
+	if err != nil {
+		Logger.Errorf("Error filtering outputs: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error filtering outputs")
+	}
+
+	txPayload, err := prepTxPayload(protoParas, unspentOutputs, walletObject.Ed25519Address, walletObject.AddressSigner, hexTypedHash)
+	if err != nil {
+		Logger.Errorf("Error preparing transaction payload: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error preparing transaction payload")
+	}
+
+	hexBlockId, err := prepAndSendBlock(c, protoParas, txPayload)
+	if err != nil {
+		Logger.Errorf("Error preparing and sending block: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error preparing and sending block")
+	}
+	Logger.Infof("Block attached with ID: %v", hexBlockId)
+
+	recordAnchor(hexTypedHash, txPayload, hexBlockId, nil, nil)
+
+	return c.JSON(http.StatusOK, map[string]string{"blockId": hexBlockId, "hash": hexTypedHash})
+}
+
+// verifyTypedNotarization re-derives the typed hash from the raw message and type
+// descriptor and compares it against the MetadataFeature of the given output,
+// the same way verifyNotarization compares a plain hash string.
+func verifyTypedNotarization(c echo.Context) error {
+	var requestBody typedVerificationRequest
+
+	defer c.Request().Body.Close()
+
+	if err := json.NewDecoder(c.Request().Body).Decode(&requestBody); err != nil {
+		Logger.Errorf("Error decoding typed verification request: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error decoding request body")
+	}
+
+	outputID, err := iotago.OutputIDFromHex(requestBody.OutputID)
+	if err != nil {
+		Logger.Errorf("Error converting outputID string: %v", requestBody.OutputID)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error converting outputID string")
+	}
+
+	protoParas := deps.NodeBridge.ProtocolParameters()
+
+	typedHash, err := hashTypedMessage(protoParas.NetworkID(), requestBody.AppName, requestBody.Types, requestBody.Message)
+	if err != nil {
+		Logger.Errorf("Error hashing typed message: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Error hashing typed message")
+	}
+	hexTypedHash := hex.EncodeToString(typedHash[:])
+
+	ctx := c.Request().Context()
+
+	output, err := deps.INXNodeClient.OutputByID(ctx, outputID)
+	if err != nil {
+		Logger.Debug("No output found with passed outputID.")
+		return c.JSON(http.StatusOK, map[string]bool{"match": false})
+	}
+
+	basicOutput, ok := output.(*iotago.BasicOutput)
+	if !ok {
+		Logger.Error("Output is not of type *iotago.BasicOutput")
+		return echo.NewHTTPError(http.StatusInternalServerError, "Unexpected output type")
+	}
+
+	metadataFeature := basicOutput.FeatureSet().MetadataFeature()
+	if metadataFeature != nil && string(metadataFeature.Data) == hexTypedHash {
+		Logger.Debugf("Matching typed hash found: %v", hexTypedHash)
+		return c.JSON(http.StatusOK, map[string]bool{"match": true})
+	}
+
+	Logger.Debug("No matching metadata feature found in output.")
+	return c.JSON(http.StatusOK, map[string]bool{"match": false})
+}
+
+// hashTypedMessage canonically encodes message according to types and hashes it,
+// domain-separated by the network ID and appName, so the same document produces
+// the same hash on any client able to reproduce the canonical encoding.
+func hashTypedMessage(networkID uint64, appName string, types TypeDescriptor, message json.RawMessage) ([32]byte, error) {
+	var decoded map[string]any
+	if err := json.Unmarshal(message, &decoded); err != nil {
+		return [32]byte{}, fmt.Errorf("failed to decode typed message: %v", err)
+	}
+
+	for field := range types {
+		if _, ok := decoded[field]; !ok {
+			return [32]byte{}, fmt.Errorf("typed message is missing field %q declared in type descriptor", field)
+		}
+	}
+
+	encodedMessage, err := canonicalEncodeTyped(decoded, types)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("failed to canonically encode typed message: %v", err)
+	}
+
+	separator := domainSeparator(networkID, appName)
+
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(separator[:])
+	hasher.Write(encodedMessage)
+
+	var digest [32]byte
+	copy(digest[:], hasher.Sum(nil))
+
+	return digest, nil
+}
+
+// domainSeparator returns the Keccak256 hash binding a typed hash to the node's
+// network ID and the caller-supplied application name, the same way EIP-712
+// binds typed data to a verifying contract and chain ID.
+func domainSeparator(networkID uint64, appName string) [32]byte {
+	var networkIDBytes [8]byte
+	binary.BigEndian.PutUint64(networkIDBytes[:], networkID)
+
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(domainSeparatorPrefix))
+	hasher.Write(networkIDBytes[:])
+	hasher.Write([]byte(appName))
+
+	var digest [32]byte
+	copy(digest[:], hasher.Sum(nil))
+
+	return digest
+}
+
+// canonicalEncode deterministically encodes a decoded JSON value so that the
+// same logical document always produces the same bytes, independent of key
+// order or client-side JSON formatting: object keys are sorted lexicographically,
+// integers are encoded as fixed-width (8 byte) big-endian, and strings are
+// length-prefixed.
+func canonicalEncode(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := canonicalEncodeValue(&buf, value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Canonical encoding type tags. Every encoded value is prefixed by one of
+// these so that, for example, an object and an array carrying the same
+// flattened key/value bytes cannot hash identically: without a tag,
+// {"a":{"b":1}} and {"a":["b",1]} would encode to the exact same bytes.
+const (
+	typeTagObject byte = 1
+	typeTagArray  byte = 2
+	typeTagNumber byte = 3
+	typeTagString byte = 4
+	typeTagBool   byte = 5
+	typeTagNull   byte = 6
+	typeTagFloat  byte = 7
+	typeTagBytes  byte = 8
+)
+
+// numericTypeWidths maps the fixed-width integer type names accepted in a
+// TypeDescriptor to the byte width implied by the type name itself, e.g.
+// "uint32" is encoded 4 bytes wide rather than the default 8.
+var numericTypeWidths = map[string]int{
+	"uint8":  1,
+	"int8":   1,
+	"uint16": 2,
+	"int16":  2,
+	"uint32": 4,
+	"int32":  4,
+	"uint64": 8,
+	"int64":  8,
+}
+
+// canonicalEncodeTyped encodes decoded the same way canonicalEncodeValue
+// encodes a top-level object, except that a field declared in types is
+// encoded according to its declared type via canonicalEncodeTypedValue
+// rather than having its encoding inferred purely from its decoded Go type.
+func canonicalEncodeTyped(decoded map[string]any, types TypeDescriptor) ([]byte, error) {
+	var buf bytes.Buffer
+
+	keys := make([]string, 0, len(decoded))
+	for key := range decoded {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	buf.WriteByte(typeTagObject)
+	canonicalEncodeCountPrefix(&buf, len(keys))
+
+	for _, key := range keys {
+		canonicalEncodeLengthPrefixed(&buf, []byte(key))
+
+		if declaredType, ok := types[key]; ok {
+			if err := canonicalEncodeTypedValue(&buf, declaredType, decoded[key]); err != nil {
+				return nil, fmt.Errorf("field %q: %v", key, err)
+			}
+
+			continue
+		}
+
+		if err := canonicalEncodeValue(&buf, decoded[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// canonicalEncodeTypedValue encodes value the way declaredType - a type name
+// from a TypeDescriptor, e.g. "uint64" or "bytes" - says it should be encoded,
+// instead of only looking at value's dynamic Go type: a declared integer type
+// is validated as integral and written fixed-width big-endian at its declared
+// width, and "bytes" is decoded from a hex string and written length-prefixed
+// as raw bytes rather than as a length-prefixed string. Any other declared
+// type falls back to the untyped encoding.
+func canonicalEncodeTypedValue(buf *bytes.Buffer, declaredType string, value any) error {
+	if width, ok := numericTypeWidths[declaredType]; ok {
+		number, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("declared type %q requires a JSON number, got %T", declaredType, value)
+		}
+
+		integer := int64(number)
+		if number != math.Trunc(number) || float64(integer) != number {
+			return fmt.Errorf("declared type %q requires an integer value, got %v", declaredType, number)
+		}
+
+		fixedWidthBytes := make([]byte, width)
+		switch width {
+		case 1:
+			fixedWidthBytes[0] = byte(integer)
+		case 2:
+			binary.BigEndian.PutUint16(fixedWidthBytes, uint16(integer))
+		case 4:
+			binary.BigEndian.PutUint32(fixedWidthBytes, uint32(integer))
+		case 8:
+			binary.BigEndian.PutUint64(fixedWidthBytes, uint64(integer))
+		}
+
+		buf.WriteByte(typeTagNumber)
+		buf.Write(fixedWidthBytes)
+
+		return nil
+	}
+
+	if declaredType == "bytes" {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("declared type \"bytes\" requires a hex-encoded JSON string, got %T", value)
+		}
+
+		raw, err := hex.DecodeString(str)
+		if err != nil {
+			return fmt.Errorf("declared type \"bytes\" requires a hex-encoded JSON string: %v", err)
+		}
+
+		buf.WriteByte(typeTagBytes)
+		canonicalEncodeLengthPrefixed(buf, raw)
+
+		return nil
+	}
+
+	return canonicalEncodeValue(buf, value)
+}
+
+func canonicalEncodeValue(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		buf.WriteByte(typeTagObject)
+		canonicalEncodeCountPrefix(buf, len(keys))
+
+		for _, key := range keys {
+			canonicalEncodeLengthPrefixed(buf, []byte(key))
+			if err := canonicalEncodeValue(buf, v[key]); err != nil {
+				return err
+			}
+		}
+
+	case []any:
+		buf.WriteByte(typeTagArray)
+		canonicalEncodeCountPrefix(buf, len(v))
+
+		for _, item := range v {
+			if err := canonicalEncodeValue(buf, item); err != nil {
+				return err
+			}
+		}
+
+	case float64:
+		// encoding/json decodes all JSON numbers as float64. An integral value is
+		// encoded fixed-width for determinism; a non-integral value is encoded as
+		// its raw IEEE-754 bits under a distinct tag instead of being truncated,
+		// so e.g. 19.99 and 19.42 can never collapse into the same int64(19) and
+		// hash identically.
+		integer := int64(v)
+		if v == math.Trunc(v) && float64(integer) == v {
+			buf.WriteByte(typeTagNumber)
+			if err := binary.Write(buf, binary.BigEndian, integer); err != nil {
+				return err
+			}
+
+			break
+		}
+
+		buf.WriteByte(typeTagFloat)
+		if err := binary.Write(buf, binary.BigEndian, math.Float64bits(v)); err != nil {
+			return err
+		}
+
+	case string:
+		buf.WriteByte(typeTagString)
+		canonicalEncodeLengthPrefixed(buf, []byte(v))
+
+	case bool:
+		buf.WriteByte(typeTagBool)
+		if v {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+
+	case nil:
+		buf.WriteByte(typeTagNull)
+
+	default:
+		return fmt.Errorf("canonicalEncode: unsupported value type %T", value)
+	}
+
+	return nil
+}
+
+// canonicalEncodeCountPrefix writes count as an 8-byte big-endian arity
+// prefix for a container (object field count or array length), so e.g. {}
+// nested one level deeper can never be mistaken for its flattened contents.
+func canonicalEncodeCountPrefix(buf *bytes.Buffer, count int) {
+	var countBytes [8]byte
+	binary.BigEndian.PutUint64(countBytes[:], uint64(count))
+	buf.Write(countBytes[:])
+}
+
+// canonicalEncodeLengthPrefixed writes data prefixed by its 8-byte big-endian
+// length so that e.g. the strings "ab","c" and "a","bc" never collide.
+func canonicalEncodeLengthPrefixed(buf *bytes.Buffer, data []byte) {
+	var lengthBytes [8]byte
+	binary.BigEndian.PutUint64(lengthBytes[:], uint64(len(data)))
+	buf.Write(lengthBytes[:])
+	buf.Write(data)
+}
@@ -0,0 +1,136 @@
+package notarizer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalEncode(t *testing.T) {
+	t.Run("Key order does not affect encoding", func(t *testing.T) {
+		var a, b map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(`{"title":"doc","version":1}`), &a))
+		assert.NoError(t, json.Unmarshal([]byte(`{"version":1,"title":"doc"}`), &b))
+
+		encodedA, err := canonicalEncode(a)
+		assert.NoError(t, err)
+		encodedB, err := canonicalEncode(b)
+		assert.NoError(t, err)
+
+		assert.Equal(t, encodedA, encodedB)
+	})
+
+	t.Run("Different values produce different encodings", func(t *testing.T) {
+		var a, b map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(`{"title":"doc"}`), &a))
+		assert.NoError(t, json.Unmarshal([]byte(`{"title":"other"}`), &b))
+
+		encodedA, err := canonicalEncode(a)
+		assert.NoError(t, err)
+		encodedB, err := canonicalEncode(b)
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, encodedA, encodedB)
+	})
+
+	t.Run("An object and an array with the same flattened content encode differently", func(t *testing.T) {
+		var a, b map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(`{"a":{"b":1}}`), &a))
+		assert.NoError(t, json.Unmarshal([]byte(`{"a":["b",1]}`), &b))
+
+		encodedA, err := canonicalEncode(a)
+		assert.NoError(t, err)
+		encodedB, err := canonicalEncode(b)
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, encodedA, encodedB)
+	})
+}
+
+func TestHashTypedMessage(t *testing.T) {
+	t.Run("Same document and domain produce the same hash", func(t *testing.T) {
+		types := TypeDescriptor{"title": "string", "version": "uint64"}
+		message := json.RawMessage(`{"title":"doc","version":1}`)
+
+		hashA, err := hashTypedMessage(1, "inx-notarizer", types, message)
+		assert.NoError(t, err)
+		hashB, err := hashTypedMessage(1, "inx-notarizer", types, message)
+		assert.NoError(t, err)
+
+		assert.Equal(t, hashA, hashB)
+		assert.Len(t, hex.EncodeToString(hashA[:]), 64)
+	})
+
+	t.Run("Different network ID changes the hash", func(t *testing.T) {
+		types := TypeDescriptor{"title": "string"}
+		message := json.RawMessage(`{"title":"doc"}`)
+
+		hashA, err := hashTypedMessage(1, "inx-notarizer", types, message)
+		assert.NoError(t, err)
+		hashB, err := hashTypedMessage(2, "inx-notarizer", types, message)
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, hashA, hashB)
+	})
+
+	t.Run("Missing declared field is rejected", func(t *testing.T) {
+		types := TypeDescriptor{"title": "string", "author": "string"}
+		message := json.RawMessage(`{"title":"doc"}`)
+
+		_, err := hashTypedMessage(1, "inx-notarizer", types, message)
+		assert.Error(t, err)
+	})
+
+	t.Run("Matches a fixed reference hash", func(t *testing.T) {
+		// Reproducible cross-language test vector: any client implementing the
+		// same canonical encoding (sorted keys, typed container tags, fixed-width
+		// big-endian uint64, length-prefixed bytes) must derive this exact hash
+		// for this exact input, independent of this Go implementation.
+		types := TypeDescriptor{"title": "string", "version": "uint64", "signature": "bytes"}
+		message := json.RawMessage(`{"title":"doc","version":1,"signature":"deadbeef"}`)
+
+		hash, err := hashTypedMessage(7, "inx-notarizer", types, message)
+		assert.NoError(t, err)
+		assert.Equal(t, "26d5f5b4851552f4f13693d1ffd4c2b0d56a7d65de24b1ee5e891f7beac312df", hex.EncodeToString(hash[:]))
+	})
+
+	t.Run("Declared uint64 field must be an integer", func(t *testing.T) {
+		types := TypeDescriptor{"price": "uint64"}
+		message := json.RawMessage(`{"price":19.99}`)
+
+		_, err := hashTypedMessage(1, "inx-notarizer", types, message)
+		assert.Error(t, err)
+	})
+
+	t.Run("Undeclared non-integer fields no longer collide", func(t *testing.T) {
+		hashA, err := hashTypedMessage(1, "inx-notarizer", TypeDescriptor{}, json.RawMessage(`{"price":19.99}`))
+		assert.NoError(t, err)
+		hashB, err := hashTypedMessage(1, "inx-notarizer", TypeDescriptor{}, json.RawMessage(`{"price":19.42}`))
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, hashA, hashB)
+	})
+
+	t.Run("Declared bytes field decodes hex instead of length-prefixing the string", func(t *testing.T) {
+		typesAsBytes := TypeDescriptor{"data": "bytes"}
+		typesAsString := TypeDescriptor{"data": "string"}
+		message := json.RawMessage(`{"data":"deadbeef"}`)
+
+		hashAsBytes, err := hashTypedMessage(1, "inx-notarizer", typesAsBytes, message)
+		assert.NoError(t, err)
+		hashAsString, err := hashTypedMessage(1, "inx-notarizer", typesAsString, message)
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, hashAsBytes, hashAsString)
+	})
+
+	t.Run("Declared bytes field rejects a non-hex string", func(t *testing.T) {
+		types := TypeDescriptor{"data": "bytes"}
+		message := json.RawMessage(`{"data":"not hex"}`)
+
+		_, err := hashTypedMessage(1, "inx-notarizer", types, message)
+		assert.Error(t, err)
+	})
+}
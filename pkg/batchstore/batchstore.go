@@ -0,0 +1,281 @@
+// Package batchstore persists flushed notarization batches so that pending
+// Merkle proofs remain servable across restarts, and persists not-yet-flushed
+// batches so hashes buffered before a flush are not silently lost on restart
+// or on a flush attempt that fails partway.
+package batchstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// FlushedBatch is the on-disk record of a batch once its Merkle root has been
+// anchored on-chain.
+type FlushedBatch struct {
+	Root     [32]byte   `json:"root"`
+	Leaves   [][32]byte `json:"leaves"`
+	OutputID string     `json:"outputID"`
+}
+
+// PendingBatch is the on-disk record of a batch that has been buffering
+// hashes but has not yet been anchored on-chain.
+type PendingBatch struct {
+	Hashes    []string   `json:"hashes"`
+	Leaves    [][32]byte `json:"leaves"`
+	CreatedAt int64      `json:"createdAt"` // unix seconds
+}
+
+// Store persists FlushedBatch and PendingBatch records keyed by batch ID.
+type Store interface {
+	// SaveBatch persists batch under batchID, overwriting any existing record.
+	SaveBatch(batchID string, batch FlushedBatch) error
+
+	// LoadBatch returns the batch previously saved under batchID. The second
+	// return value is false if no such batch exists.
+	LoadBatch(batchID string) (FlushedBatch, bool, error)
+
+	// SavePending persists batch's not-yet-flushed state under batchID,
+	// overwriting any existing record, so it survives a restart.
+	SavePending(batchID string, batch PendingBatch) error
+
+	// DeletePending removes the persisted pending record for batchID, once it
+	// has been flushed. It is not an error if no such record exists.
+	DeletePending(batchID string) error
+
+	// LoadPendingBatches returns every persisted pending batch, keyed by
+	// batch ID, so they can be recovered after a restart.
+	LoadPendingBatches() (map[string]PendingBatch, error)
+}
+
+// FileStore is a Store backed by a plain directory on the local filesystem,
+// one JSON file per batch.
+type FileStore struct {
+	directory string
+}
+
+// NewFileStore creates a FileStore rooted at directory, creating it if it does
+// not yet exist.
+func NewFileStore(directory string) (*FileStore, error) {
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create batch store directory: %w", err)
+	}
+
+	return &FileStore{directory: directory}, nil
+}
+
+// SaveBatch implements Store.
+func (s *FileStore) SaveBatch(batchID string, batch FlushedBatch) error {
+	data, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch %q: %w", batchID, err)
+	}
+
+	if err := os.WriteFile(s.pathForBatch(batchID), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write batch %q: %w", batchID, err)
+	}
+
+	return nil
+}
+
+// LoadBatch implements Store.
+func (s *FileStore) LoadBatch(batchID string) (FlushedBatch, bool, error) {
+	data, err := os.ReadFile(s.pathForBatch(batchID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return FlushedBatch{}, false, nil
+		}
+
+		return FlushedBatch{}, false, fmt.Errorf("failed to read batch %q: %w", batchID, err)
+	}
+
+	var batch FlushedBatch
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return FlushedBatch{}, false, fmt.Errorf("failed to unmarshal batch %q: %w", batchID, err)
+	}
+
+	return batch, true, nil
+}
+
+// SavePending implements Store.
+func (s *FileStore) SavePending(batchID string, batch PendingBatch) error {
+	data, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending batch %q: %w", batchID, err)
+	}
+
+	if err := os.WriteFile(s.pathForPending(batchID), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write pending batch %q: %w", batchID, err)
+	}
+
+	return nil
+}
+
+// DeletePending implements Store.
+func (s *FileStore) DeletePending(batchID string) error {
+	if err := os.Remove(s.pathForPending(batchID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete pending batch %q: %w", batchID, err)
+	}
+
+	return nil
+}
+
+// LoadPendingBatches implements Store.
+func (s *FileStore) LoadPendingBatches() (map[string]PendingBatch, error) {
+	entries, err := os.ReadDir(s.directory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list batch store directory: %w", err)
+	}
+
+	batches := make(map[string]PendingBatch)
+
+	for _, entry := range entries {
+		batchID, ok := strings.CutSuffix(entry.Name(), pendingSuffix)
+		if entry.IsDir() || !ok {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.directory, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pending batch %q: %w", batchID, err)
+		}
+
+		var batch PendingBatch
+		if err := json.Unmarshal(data, &batch); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal pending batch %q: %w", batchID, err)
+		}
+
+		batches[batchID] = batch
+	}
+
+	return batches, nil
+}
+
+// pendingSuffix distinguishes a not-yet-flushed batch's file from a flushed
+// batch's plain "<batchID>.json" file.
+const pendingSuffix = ".pending.json"
+
+func (s *FileStore) pathForBatch(batchID string) string {
+	return filepath.Join(s.directory, batchID+".json")
+}
+
+func (s *FileStore) pathForPending(batchID string) string {
+	return filepath.Join(s.directory, batchID+pendingSuffix)
+}
+
+var (
+	batchesBucket = []byte("batches")
+	pendingBucket = []byte("pending")
+)
+
+// BoltStore is a Store backed by a single embedded bbolt database file,
+// rather than one JSON file on disk per batch, the same way
+// documentstore.BoltStore backs the document store with one database file
+// instead of many.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a BoltStore at path.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open batch store database at %q: %w", path, err)
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(batchesBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(pendingBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize batch store database at %q: %w", path, err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// SaveBatch implements Store.
+func (s *BoltStore) SaveBatch(batchID string, batch FlushedBatch) error {
+	data, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch %q: %w", batchID, err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(batchesBucket).Put([]byte(batchID), data)
+	})
+}
+
+// LoadBatch implements Store.
+func (s *BoltStore) LoadBatch(batchID string) (FlushedBatch, bool, error) {
+	var batch FlushedBatch
+	found := false
+
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		stored := tx.Bucket(batchesBucket).Get([]byte(batchID))
+		if stored == nil {
+			return nil
+		}
+
+		found = true
+
+		return json.Unmarshal(stored, &batch)
+	}); err != nil {
+		return FlushedBatch{}, false, fmt.Errorf("failed to read batch %q: %w", batchID, err)
+	}
+
+	return batch, found, nil
+}
+
+// SavePending implements Store.
+func (s *BoltStore) SavePending(batchID string, batch PendingBatch) error {
+	data, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending batch %q: %w", batchID, err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(pendingBucket).Put([]byte(batchID), data)
+	})
+}
+
+// DeletePending implements Store.
+func (s *BoltStore) DeletePending(batchID string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(pendingBucket).Delete([]byte(batchID))
+	})
+}
+
+// LoadPendingBatches implements Store.
+func (s *BoltStore) LoadPendingBatches() (map[string]PendingBatch, error) {
+	batches := make(map[string]PendingBatch)
+
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(pendingBucket).ForEach(func(batchID, data []byte) error {
+			var batch PendingBatch
+			if err := json.Unmarshal(data, &batch); err != nil {
+				return fmt.Errorf("failed to unmarshal pending batch %q: %w", batchID, err)
+			}
+
+			batches[string(batchID)] = batch
+
+			return nil
+		})
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list pending batches: %w", err)
+	}
+
+	return batches, nil
+}
+
+// Close releases the underlying database.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,139 @@
+package batchstore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStoreFlushedBatch(t *testing.T) {
+	t.Run("SaveBatch then LoadBatch returns the stored batch", func(t *testing.T) {
+		store, err := NewFileStore(t.TempDir())
+		assert.NoError(t, err)
+
+		batch := FlushedBatch{Root: [32]byte{1}, Leaves: [][32]byte{{2}, {3}}, OutputID: "deadbeef"}
+		assert.NoError(t, store.SaveBatch("batch1", batch))
+
+		loaded, found, err := store.LoadBatch("batch1")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, batch, loaded)
+	})
+
+	t.Run("LoadBatch on unknown ID returns found=false", func(t *testing.T) {
+		store, err := NewFileStore(t.TempDir())
+		assert.NoError(t, err)
+
+		_, found, err := store.LoadBatch("unknown")
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestFileStorePendingBatch(t *testing.T) {
+	t.Run("SavePending then LoadPendingBatches returns the stored batch", func(t *testing.T) {
+		store, err := NewFileStore(t.TempDir())
+		assert.NoError(t, err)
+
+		pending := PendingBatch{Hashes: []string{"a", "b"}, Leaves: [][32]byte{{1}, {2}}, CreatedAt: 100}
+		assert.NoError(t, store.SavePending("batch1", pending))
+
+		batches, err := store.LoadPendingBatches()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]PendingBatch{"batch1": pending}, batches)
+	})
+
+	t.Run("DeletePending removes the record and is a no-op if already gone", func(t *testing.T) {
+		store, err := NewFileStore(t.TempDir())
+		assert.NoError(t, err)
+
+		assert.NoError(t, store.SavePending("batch1", PendingBatch{Hashes: []string{"a"}}))
+		assert.NoError(t, store.DeletePending("batch1"))
+
+		batches, err := store.LoadPendingBatches()
+		assert.NoError(t, err)
+		assert.Empty(t, batches)
+
+		assert.NoError(t, store.DeletePending("batch1"))
+	})
+
+	t.Run("A flushed batch does not show up as pending", func(t *testing.T) {
+		store, err := NewFileStore(t.TempDir())
+		assert.NoError(t, err)
+
+		assert.NoError(t, store.SaveBatch("batch1", FlushedBatch{OutputID: "deadbeef"}))
+
+		batches, err := store.LoadPendingBatches()
+		assert.NoError(t, err)
+		assert.Empty(t, batches)
+	})
+}
+
+func TestBoltStoreFlushedBatch(t *testing.T) {
+	t.Run("SaveBatch then LoadBatch returns the stored batch", func(t *testing.T) {
+		store, err := NewBoltStore(filepath.Join(t.TempDir(), "batches.db"))
+		assert.NoError(t, err)
+		defer store.Close()
+
+		batch := FlushedBatch{Root: [32]byte{1}, Leaves: [][32]byte{{2}, {3}}, OutputID: "deadbeef"}
+		assert.NoError(t, store.SaveBatch("batch1", batch))
+
+		loaded, found, err := store.LoadBatch("batch1")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, batch, loaded)
+	})
+
+	t.Run("LoadBatch on unknown ID returns found=false", func(t *testing.T) {
+		store, err := NewBoltStore(filepath.Join(t.TempDir(), "batches.db"))
+		assert.NoError(t, err)
+		defer store.Close()
+
+		_, found, err := store.LoadBatch("unknown")
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestBoltStorePendingBatch(t *testing.T) {
+	t.Run("SavePending then LoadPendingBatches returns the stored batch", func(t *testing.T) {
+		store, err := NewBoltStore(filepath.Join(t.TempDir(), "batches.db"))
+		assert.NoError(t, err)
+		defer store.Close()
+
+		pending := PendingBatch{Hashes: []string{"a", "b"}, Leaves: [][32]byte{{1}, {2}}, CreatedAt: 100}
+		assert.NoError(t, store.SavePending("batch1", pending))
+
+		batches, err := store.LoadPendingBatches()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]PendingBatch{"batch1": pending}, batches)
+	})
+
+	t.Run("DeletePending removes the record and is a no-op if already gone", func(t *testing.T) {
+		store, err := NewBoltStore(filepath.Join(t.TempDir(), "batches.db"))
+		assert.NoError(t, err)
+		defer store.Close()
+
+		assert.NoError(t, store.SavePending("batch1", PendingBatch{Hashes: []string{"a"}}))
+		assert.NoError(t, store.DeletePending("batch1"))
+
+		batches, err := store.LoadPendingBatches()
+		assert.NoError(t, err)
+		assert.Empty(t, batches)
+
+		assert.NoError(t, store.DeletePending("batch1"))
+	})
+
+	t.Run("A flushed batch does not show up as pending", func(t *testing.T) {
+		store, err := NewBoltStore(filepath.Join(t.TempDir(), "batches.db"))
+		assert.NoError(t, err)
+		defer store.Close()
+
+		assert.NoError(t, store.SaveBatch("batch1", FlushedBatch{OutputID: "deadbeef"}))
+
+		batches, err := store.LoadPendingBatches()
+		assert.NoError(t, err)
+		assert.Empty(t, batches)
+	})
+}
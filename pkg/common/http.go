@@ -2,49 +2,163 @@ package common
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"time"
 )
 
-func GetRequest(endPoint string, token string) (*http.Response, error) {
-	httpRequest, err := http.NewRequest(http.MethodGet, endPoint, nil)
-	if err != nil {
-		return nil, err
-	}
+const (
+	// defaultTimeout bounds how long a single HTTP request is allowed to
+	// take before it is aborted, so a hung IOTA/INX endpoint can no longer
+	// block the caller indefinitely.
+	defaultTimeout = 5 * time.Second
 
-	httpRequest.Header.Set("Accept", "application/json")
-	if len(token) > 0 {
-		httpRequest.Header.Set("Authorization", "Bearer "+token)
-	}
-	res, err := http.DefaultClient.Do(httpRequest)
-	if err != nil {
-		return nil, err
-	}
+	// defaultMaxRetries is how many additional attempts are made after a
+	// transient failure before giving up.
+	defaultMaxRetries = 5
+
+	// defaultRetryWait is the fixed backoff between retry attempts.
+	defaultRetryWait = 200 * time.Millisecond
+)
+
+// Client wraps an *http.Client with a bounded retry loop, so transient
+// network errors and 5xx responses are retried automatically and a caller's
+// context can still cancel outstanding requests, e.g. on shutdown.
+type Client struct {
+	HTTPClient *http.Client
+	MaxRetries int
+	RetryWait  time.Duration
+}
 
-	return res, nil
+// NewClient returns a Client with the given timeout and the package's
+// default retry policy. Its requests carry no credentials; wrap
+// http.DefaultTransport with NewBearerTransport/NewBasicAuthTransport and
+// pass it to NewClientWithTransport to authenticate requests instead of
+// threading a token string through every call.
+func NewClient(timeout time.Duration) *Client {
+	return NewClientWithTransport(timeout, nil)
 }
 
-func PostRequest(endPoint string, token string, payload any) (*http.Response, error) {
-	jsonBody, err := json.Marshal(payload)
-	if err != nil {
-		return nil, err
+// NewClientWithTransport returns a Client with the given timeout, retry
+// policy and underlying http.RoundTripper. Passing a RoundTripper built from
+// NewBearerTransport/NewBasicAuthTransport lets the notarizer stack auth
+// alongside other middlewares (logging, metrics, OAuth2, mTLS) without
+// changing call sites.
+func NewClientWithTransport(timeout time.Duration, transport http.RoundTripper) *Client {
+	return &Client{
+		HTTPClient: &http.Client{Timeout: timeout, Transport: transport},
+		MaxRetries: defaultMaxRetries,
+		RetryWait:  defaultRetryWait,
 	}
+}
+
+// DefaultClient is used by the package-level GetRequest/PostRequest helpers.
+var DefaultClient = NewClient(defaultTimeout)
+
+// GetRequest issues a GET request via DefaultClient.
+func GetRequest(ctx context.Context, endPoint string) (*http.Response, error) {
+	return DefaultClient.Get(ctx, endPoint)
+}
+
+// PostRequest issues a POST request via DefaultClient.
+func PostRequest(ctx context.Context, endPoint string, payload any) (*http.Response, error) {
+	return DefaultClient.Post(ctx, endPoint, payload)
+}
+
+// Get issues a GET request, retrying on transient failures. Credentials, if
+// any, are attached by the Client's configured http.RoundTripper.
+func (c *Client) Get(ctx context.Context, endPoint string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, endPoint, nil)
+}
 
-	bodyReader := bytes.NewReader(jsonBody)
-	httpRequest, err := http.NewRequest(http.MethodPost, endPoint, bodyReader)
-	if err != nil {
-		return nil, err
+// Post issues a POST request with a JSON-encoded payload, retrying on
+// transient failures. Credentials, if any, are attached by the Client's
+// configured http.RoundTripper.
+func (c *Client) Post(ctx context.Context, endPoint string, payload any) (*http.Response, error) {
+	var body []byte
+	if payload != nil {
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
 	}
-	httpRequest.Header.Set("Content-Type", "application/json")
 
-	if len(token) > 0 {
-		httpRequest.Header.Set("Authorization", "Bearer "+token)
+	return c.do(ctx, http.MethodPost, endPoint, body)
+}
+
+// do builds and sends an HTTP request, retrying up to MaxRetries times with
+// RetryWait between attempts on transient network errors and 5xx responses.
+func (c *Client) do(ctx context.Context, method string, endPoint string, body []byte) (*http.Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(c.RetryWait):
+			}
+		}
+
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(body)
+		}
+
+		httpRequest, err := http.NewRequestWithContext(ctx, method, endPoint, bodyReader)
+		if err != nil {
+			return nil, err
+		}
+
+		httpRequest.Header.Set("Accept", "application/json")
+		if body != nil {
+			httpRequest.Header.Set("Content-Type", "application/json")
+		}
+
+		res, err := c.HTTPClient.Do(httpRequest)
+		if err != nil {
+			lastErr = err
+			if ctx.Err() != nil || !isRetryableError(err) {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if res.StatusCode >= http.StatusInternalServerError && attempt < c.MaxRetries {
+			lastErr = fmt.Errorf("server returned status %d", res.StatusCode)
+			res.Body.Close()
+
+			continue
+		}
+
+		return res, nil
 	}
 
-	res, err := http.DefaultClient.Do(httpRequest)
-	if err != nil {
-		return nil, err
+	return nil, lastErr
+}
+
+// isRetryableError reports whether err is a transient network error worth
+// retrying, such as a timeout or a connection that was reset or refused.
+//
+// http.Client.Do wraps essentially every transport error in a *url.Error,
+// and *url.Error itself satisfies net.Error regardless of what its
+// underlying cause is - its Timeout()/Temporary() methods just delegate to
+// that cause, returning false if the cause doesn't support them. So merely
+// checking errors.As(err, &netErr) accepts any error at all; Timeout() and
+// Temporary() have to actually be called to tell a permanent failure (bad
+// host, TLS handshake failure, malformed request) from a transient one.
+func isRetryableError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout() || netErr.Temporary() //nolint:staticcheck // Temporary is deprecated but still the best signal net.Error exposes
 	}
 
-	return res, nil
+	return errors.Is(err, io.ErrUnexpectedEOF)
 }
@@ -0,0 +1,138 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientGetRetriesOn5xx(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(time.Second)
+	client.RetryWait = time.Millisecond
+
+	res, err := client.Get(context.Background(), server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestClientGetGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(time.Second)
+	client.MaxRetries = 2
+	client.RetryWait = time.Millisecond
+
+	res, err := client.Get(context.Background(), server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestClientGetCancelledByContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(time.Second)
+	client.RetryWait = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.Get(ctx, server.URL)
+	assert.Error(t, err)
+}
+
+// fakeNetError is a net.Error whose Timeout/Temporary results are fixed by a
+// test, so isRetryableError's behavior can be exercised without depending on
+// a real (and potentially flaky, sandbox-dependent) network/DNS round-trip.
+type fakeNetError struct {
+	timeout, temporary bool
+}
+
+func (e fakeNetError) Error() string   { return "fake net error" }
+func (e fakeNetError) Timeout() bool   { return e.timeout }
+func (e fakeNetError) Temporary() bool { return e.temporary } //nolint:staticcheck // mirrors the deprecated net.Error method under test
+
+func TestClientGetDoesNotRetryPermanentFailure(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(time.Second)
+	client.MaxRetries = 3
+	client.RetryWait = time.Millisecond
+	client.HTTPClient.Transport = roundTripFunc(func(*http.Request) (*http.Response, error) {
+		atomic.AddInt32(&attempts, 1)
+		return nil, fakeNetError{}
+	})
+
+	_, err := client.Get(context.Background(), server.URL)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts), "a permanent failure must not be retried")
+}
+
+// roundTripFunc adapts a function to http.RoundTripper, so a Client's
+// transport can be replaced with fixed, deterministic error responses.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestIsRetryableError(t *testing.T) {
+	t.Run("A real timeout is retryable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(50 * time.Millisecond)
+		}))
+		defer server.Close()
+
+		client := NewClient(time.Millisecond)
+		_, err := client.HTTPClient.Get(server.URL)
+		assert.Error(t, err)
+		assert.True(t, isRetryableError(err))
+	})
+
+	t.Run("A permanent failure wrapped in *url.Error is not retryable", func(t *testing.T) {
+		// *url.Error itself satisfies net.Error regardless of its cause, so
+		// this only stays false because isRetryableError calls
+		// Timeout()/Temporary() on the underlying fakeNetError instead of
+		// merely checking that the wrapper implements the interface.
+		err := &url.Error{Op: "Get", URL: "http://example.invalid", Err: fakeNetError{}}
+		assert.False(t, isRetryableError(err))
+	})
+
+	t.Run("A transient failure wrapped in *url.Error is retryable", func(t *testing.T) {
+		err := &url.Error{Op: "Get", URL: "http://example.invalid", Err: fakeNetError{temporary: true}}
+		assert.True(t, isRetryableError(err))
+	})
+}
@@ -0,0 +1,120 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// APIError is returned by DoJSON when the server responds with a non-2xx
+// status and a JSON body of the shape {"Error": "..."}.
+type APIError struct {
+	Code    int    `json:"-"`
+	Message string `json:"Error"`
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error (status %d): %s", e.Code, e.Message)
+}
+
+// Is reports whether target is an *APIError matching e on every field target
+// sets, so callers can do errors.Is(err, &common.APIError{Code: http.StatusNotFound})
+// without also pinning down Message.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	if t.Code != 0 && t.Code != e.Code {
+		return false
+	}
+	if t.Message != "" && t.Message != e.Message {
+		return false
+	}
+
+	return true
+}
+
+// HTTPError is returned by DoJSON when the server responds with a non-2xx
+// status and a body that isn't a valid APIError.
+type HTTPError struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Error implements error.
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("unexpected response status %d: %s", e.StatusCode, string(e.Body))
+}
+
+// DoJSON issues a request via DefaultClient and decodes its response, see
+// Client.DoJSON.
+func DoJSON(ctx context.Context, method string, endPoint string, payload any, out any) error {
+	return DefaultClient.DoJSON(ctx, method, endPoint, payload, out)
+}
+
+// GetJSON issues a GET request via DefaultClient and decodes its response
+// into out.
+func GetJSON(ctx context.Context, endPoint string, out any) error {
+	return DefaultClient.DoJSON(ctx, http.MethodGet, endPoint, nil, out)
+}
+
+// PostJSON issues a POST request via DefaultClient and decodes its response
+// into out.
+func PostJSON(ctx context.Context, endPoint string, payload any, out any) error {
+	return DefaultClient.DoJSON(ctx, http.MethodPost, endPoint, payload, out)
+}
+
+// DoJSON issues method against endPoint, JSON-encoding payload for POST
+// requests. On a non-2xx response it attempts to decode the body as an
+// APIError and, failing that, returns an HTTPError carrying the raw body. On
+// a 2xx response it decodes the body into out, which may be nil if the
+// caller doesn't need it.
+func (c *Client) DoJSON(ctx context.Context, method string, endPoint string, payload any, out any) error {
+	var (
+		res *http.Response
+		err error
+	)
+
+	switch method {
+	case http.MethodGet:
+		res, err = c.Get(ctx, endPoint)
+	case http.MethodPost:
+		res, err = c.Post(ctx, endPoint, payload)
+	default:
+		return fmt.Errorf("unsupported method %q", method)
+	}
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("reading response body: %w", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		var apiErr APIError
+		if jsonErr := json.Unmarshal(body, &apiErr); jsonErr == nil && apiErr.Message != "" {
+			apiErr.Code = res.StatusCode
+
+			return &apiErr
+		}
+
+		return &HTTPError{StatusCode: res.StatusCode, Body: body}
+	}
+
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("decoding response body: %w", err)
+	}
+
+	return nil
+}
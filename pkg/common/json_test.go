@@ -0,0 +1,63 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoJSONDecodesSuccessResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"blockId":"abc"}`))
+	}))
+	defer server.Close()
+
+	var out struct {
+		BlockID string `json:"blockId"`
+	}
+
+	err := GetJSON(context.Background(), server.URL, &out)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", out.BlockID)
+}
+
+func TestDoJSONReturnsAPIErrorOnJSONErrorBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"Error":"invalid hash"}`))
+	}))
+	defer server.Close()
+
+	err := GetJSON(context.Background(), server.URL, nil)
+
+	var apiErr *APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusBadRequest, apiErr.Code)
+		assert.Equal(t, "invalid hash", apiErr.Message)
+	}
+	assert.True(t, errors.Is(err, &APIError{Code: http.StatusBadRequest}))
+}
+
+func TestDoJSONReturnsHTTPErrorOnNonJSONBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("boom"))
+	}))
+	defer server.Close()
+
+	client := NewClient(defaultTimeout)
+	client.MaxRetries = 0
+
+	err := client.DoJSON(context.Background(), http.MethodGet, server.URL, nil, nil)
+
+	var httpErr *HTTPError
+	if assert.ErrorAs(t, err, &httpErr) {
+		assert.Equal(t, http.StatusInternalServerError, httpErr.StatusCode)
+		assert.Equal(t, "boom", string(httpErr.Body))
+	}
+}
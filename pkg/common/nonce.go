@@ -0,0 +1,165 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Signer produces a JWS over payload, binding it to nonce and url so the
+// signature cannot be replayed against a different request, the way ACME
+// clients bind a signed request to its target endpoint.
+type Signer interface {
+	Sign(payload []byte, nonce, url string) ([]byte, error)
+}
+
+// badNonceMessage is the APIError.Message an anti-replay endpoint returns
+// when a submitted nonce was stale or already used.
+const badNonceMessage = "badNonce"
+
+// NonceSource maintains a small buffered pool of anti-replay nonces: every
+// response harvested through PostSigned contributes its Replay-Nonce header
+// to the pool (dropped if the pool is full), and when the pool is empty a
+// nonce is fetched from newNonceURL and handed straight to the caller.
+type NonceSource struct {
+	client      *Client
+	newNonceURL string
+	nonces      chan string
+}
+
+// NewNonceSource returns a NonceSource that buffers up to size nonces,
+// fetching new ones from newNonceURL via GET when the pool is empty.
+func NewNonceSource(client *Client, newNonceURL string, size int) *NonceSource {
+	return &NonceSource{
+		client:      client,
+		newNonceURL: newNonceURL,
+		nonces:      make(chan string, size),
+	}
+}
+
+// Nonce returns a nonce from the pool, fetching one from newNonceURL if the
+// pool is currently empty.
+func (s *NonceSource) Nonce(ctx context.Context) (string, error) {
+	select {
+	case nonce := <-s.nonces:
+		return nonce, nil
+	default:
+	}
+
+	return s.fetchNonce(ctx)
+}
+
+// fetchNonce queries newNonceURL for a fresh nonce.
+func (s *NonceSource) fetchNonce(ctx context.Context) (string, error) {
+	res, err := s.client.Get(ctx, s.newNonceURL)
+	if err != nil {
+		return "", fmt.Errorf("fetching new nonce: %w", err)
+	}
+	defer res.Body.Close()
+
+	nonce := res.Header.Get("Replay-Nonce")
+	if nonce == "" {
+		return "", errors.New("newNonceURL response carried no Replay-Nonce header")
+	}
+
+	return nonce, nil
+}
+
+// harvest stashes res's Replay-Nonce header in the pool, dropping it if the
+// pool is already full.
+func (s *NonceSource) harvest(res *http.Response) {
+	nonce := res.Header.Get("Replay-Nonce")
+	if nonce == "" {
+		return
+	}
+
+	select {
+	case s.nonces <- nonce:
+	default:
+	}
+}
+
+// PostSigned pulls a nonce from the pool, asks signer to produce a JWS over
+// payload bound to that nonce and url, and POSTs the JWS with the
+// "application/jose+json" content type anti-replay endpoints such as ACME
+// expect. If the server rejects the attempt with a badNonce error, it
+// retries once with the next nonce from the pool - usually the one the
+// rejecting response itself carried - or, if the pool is empty, one fetched
+// from newNonceURL.
+func (s *NonceSource) PostSigned(ctx context.Context, url string, payload any, signer Signer) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling payload: %w", err)
+	}
+
+	res, err := s.postSignedOnce(ctx, url, body, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	badNonce, err := isBadNonceResponse(res)
+	if err != nil {
+		return nil, err
+	}
+	if !badNonce {
+		return res, nil
+	}
+
+	return s.postSignedOnce(ctx, url, body, signer)
+}
+
+// postSignedOnce signs body with a single nonce and submits it, harvesting
+// the response's Replay-Nonce header back into the pool either way.
+func (s *NonceSource) postSignedOnce(ctx context.Context, url string, body []byte, signer Signer) (*http.Response, error) {
+	nonce, err := s.Nonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jws, err := signer.Sign(body, nonce, url)
+	if err != nil {
+		return nil, fmt.Errorf("signing payload: %w", err)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jws))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/jose+json")
+
+	res, err := s.client.HTTPClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	s.harvest(res)
+
+	return res, nil
+}
+
+// isBadNonceResponse reports whether res is a 400 response carrying a
+// badNonce APIError, the signal anti-replay endpoints use to ask for a fresh
+// nonce. res.Body is left intact for the caller to read afterwards.
+func isBadNonceResponse(res *http.Response) (bool, error) {
+	if res.StatusCode != http.StatusBadRequest {
+		return false, nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return false, fmt.Errorf("reading response body: %w", err)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	var apiErr APIError
+	if err := json.Unmarshal(body, &apiErr); err != nil {
+		return false, nil
+	}
+
+	return apiErr.Message == badNonceMessage, nil
+}
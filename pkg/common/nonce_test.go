@@ -0,0 +1,75 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubSigner struct {
+	signs []struct{ nonce, url string }
+}
+
+func (s *stubSigner) Sign(payload []byte, nonce, url string) ([]byte, error) {
+	s.signs = append(s.signs, struct{ nonce, url string }{nonce, url})
+	return []byte(`{"payload":"` + string(payload) + `","protected":"","signature":"sig"}`), nil
+}
+
+func TestNonceSourceFetchesFromNewNonceURLWhenEmpty(t *testing.T) {
+	newNonceServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Replay-Nonce", "nonce-1")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer newNonceServer.Close()
+
+	source := NewNonceSource(NewClient(time.Second), newNonceServer.URL, 4)
+
+	nonce, err := source.Nonce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "nonce-1", nonce)
+}
+
+func TestPostSignedRetriesOnceOnBadNonce(t *testing.T) {
+	var attempts int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		assert.Equal(t, "application/jose+json", r.Header.Get("Content-Type"))
+
+		if attempts == 1 {
+			w.Header().Set("Replay-Nonce", "stale-nonce")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"Error":"badNonce"}`))
+			return
+		}
+
+		w.Header().Set("Replay-Nonce", "unused-nonce")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	source := NewNonceSource(NewClient(time.Second), server.URL+"/new-nonce", 4)
+	source.nonces <- "first-nonce"
+
+	signer := &stubSigner{}
+
+	res, err := source.PostSigned(context.Background(), server.URL, map[string]string{"hash": "abcd"}, signer)
+	assert.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 2, attempts)
+	if assert.Len(t, signer.signs, 2) {
+		assert.Equal(t, "first-nonce", signer.signs[0].nonce)
+		assert.Equal(t, "stale-nonce", signer.signs[1].nonce)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Empty(t, body)
+}
@@ -0,0 +1,57 @@
+package common
+
+import "net/http"
+
+// authTransport wraps base and applies setAuth only to requests addressed to
+// hostname, so credentials are never attached to a request that got
+// redirected to a different host.
+type authTransport struct {
+	base     http.RoundTripper
+	hostname string
+	setAuth  func(*http.Request)
+}
+
+// NewBearerTransport returns an http.RoundTripper that injects an
+// "Authorization: Bearer <token>" header into every request addressed to
+// hostname. base is used for the actual round trip and defaults to
+// http.DefaultTransport when nil.
+func NewBearerTransport(base http.RoundTripper, hostname, token string) http.RoundTripper {
+	return &authTransport{
+		base:     base,
+		hostname: hostname,
+		setAuth: func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+token)
+		},
+	}
+}
+
+// NewBasicAuthTransport returns an http.RoundTripper that injects HTTP Basic
+// auth credentials into every request addressed to hostname, the same way
+// NewBearerTransport injects a bearer token.
+func NewBasicAuthTransport(base http.RoundTripper, hostname, username, password string) http.RoundTripper {
+	return &authTransport{
+		base:     base,
+		hostname: hostname,
+		setAuth: func(req *http.Request) {
+			req.SetBasicAuth(username, password)
+		},
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if req.URL.Host != t.hostname {
+		return base.RoundTrip(req)
+	}
+
+	// http.RoundTripper implementations must not mutate the original request.
+	cloned := req.Clone(req.Context())
+	t.setAuth(cloned)
+
+	return base.RoundTrip(cloned)
+}
@@ -0,0 +1,47 @@
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBearerTransportInjectsHeaderForConfiguredHost(t *testing.T) {
+	var gotAuth string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+
+	transport := NewBearerTransport(http.DefaultTransport, serverURL.Host, "s3cr3t")
+	client := &http.Client{Transport: transport}
+
+	_, err = client.Get(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer s3cr3t", gotAuth)
+}
+
+func TestBearerTransportSkipsHeaderForOtherHosts(t *testing.T) {
+	var gotAuth string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := NewBearerTransport(http.DefaultTransport, "example.com", "s3cr3t")
+	client := &http.Client{Transport: transport}
+
+	_, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	assert.Empty(t, gotAuth)
+}
@@ -0,0 +1,121 @@
+// Package documentstore provides pluggable storage for the full documents
+// behind a notarization, keyed by the hash that gets anchored on-chain.
+package documentstore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Store persists documents keyed by their content hash so that only the hash
+// needs to be anchored on-chain while the full document remains retrievable.
+type Store interface {
+	// Put stores data under hash, overwriting any existing document with the
+	// same hash.
+	Put(hash string, data []byte) error
+
+	// Get returns the document previously stored under hash.
+	Get(hash string) ([]byte, error)
+}
+
+// FileStore is a Store backed by a plain directory on the local filesystem,
+// one file per hash.
+type FileStore struct {
+	directory string
+}
+
+// NewFileStore creates a FileStore rooted at directory, creating it if it does
+// not yet exist.
+func NewFileStore(directory string) (*FileStore, error) {
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create document store directory: %w", err)
+	}
+
+	return &FileStore{directory: directory}, nil
+}
+
+// Put implements Store.
+func (s *FileStore) Put(hash string, data []byte) error {
+	if err := os.WriteFile(s.pathForHash(hash), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write document for hash %q: %w", hash, err)
+	}
+
+	return nil
+}
+
+// Get implements Store.
+func (s *FileStore) Get(hash string) ([]byte, error) {
+	data, err := os.ReadFile(s.pathForHash(hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document for hash %q: %w", hash, err)
+	}
+
+	return data, nil
+}
+
+func (s *FileStore) pathForHash(hash string) string {
+	return filepath.Join(s.directory, hash+".json")
+}
+
+var documentsBucket = []byte("documents")
+
+// BoltStore is a Store backed by a single embedded bbolt database file,
+// rather than one file on disk per document, the same way index.BoltIndex
+// backs the notarization index with one database file instead of many.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a BoltStore at path.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open document store database at %q: %w", path, err)
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(documentsBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize document store database at %q: %w", path, err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Put implements Store.
+func (s *BoltStore) Put(hash string, data []byte) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(documentsBucket).Put([]byte(hash), data)
+	})
+}
+
+// Get implements Store.
+func (s *BoltStore) Get(hash string) ([]byte, error) {
+	var data []byte
+
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		stored := tx.Bucket(documentsBucket).Get([]byte(hash))
+		if stored == nil {
+			return fmt.Errorf("no document stored for hash %q", hash)
+		}
+
+		data = make([]byte, len(stored))
+		copy(data, stored)
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Close releases the underlying database.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,52 @@
+package documentstore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStore(t *testing.T) {
+	t.Run("Put then Get returns the stored document", func(t *testing.T) {
+		store, err := NewFileStore(t.TempDir())
+		assert.NoError(t, err)
+
+		assert.NoError(t, store.Put("abcd1234", []byte(`{"title":"doc"}`)))
+
+		data, err := store.Get("abcd1234")
+		assert.NoError(t, err)
+		assert.Equal(t, `{"title":"doc"}`, string(data))
+	})
+
+	t.Run("Get on unknown hash fails", func(t *testing.T) {
+		store, err := NewFileStore(t.TempDir())
+		assert.NoError(t, err)
+
+		_, err = store.Get("deadbeef")
+		assert.Error(t, err)
+	})
+}
+
+func TestBoltStore(t *testing.T) {
+	t.Run("Put then Get returns the stored document", func(t *testing.T) {
+		store, err := NewBoltStore(filepath.Join(t.TempDir(), "documents.db"))
+		assert.NoError(t, err)
+		defer store.Close()
+
+		assert.NoError(t, store.Put("abcd1234", []byte(`{"title":"doc"}`)))
+
+		data, err := store.Get("abcd1234")
+		assert.NoError(t, err)
+		assert.Equal(t, `{"title":"doc"}`, string(data))
+	})
+
+	t.Run("Get on unknown hash fails", func(t *testing.T) {
+		store, err := NewBoltStore(filepath.Join(t.TempDir(), "documents.db"))
+		assert.NoError(t, err)
+		defer store.Close()
+
+		_, err = store.Get("deadbeef")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,185 @@
+// Package index maintains a local, per-hash record of every notarization the
+// plugin has attached on-chain, so verifying a hash no longer requires the
+// caller to remember its output ID.
+package index
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	hashRecordsBucket = []byte("hash_records")
+	outputHashBucket  = []byte("output_hash")
+	byTimeBucket      = []byte("by_time")
+)
+
+// AnchorRecord is one on-chain anchor of a hash.
+type AnchorRecord struct {
+	OutputID  string    `json:"outputID"`
+	BlockID   string    `json:"blockID"`
+	Timestamp time.Time `json:"timestamp"`
+	// BatchID and LeafIndex are set when the hash was anchored as part of a
+	// Merkle batch rather than on its own.
+	BatchID   string `json:"batchID,omitempty"`
+	LeafIndex *int   `json:"leafIndex,omitempty"`
+}
+
+// HistoryEntry pairs an AnchorRecord with the hash it anchors, for
+// time-range queries that span more than one hash.
+type HistoryEntry struct {
+	Hash string `json:"hash"`
+	AnchorRecord
+}
+
+// Index records notarizations as they are attached and serves them back by
+// hash or by time range.
+type Index interface {
+	// RecordAnchor appends record to the list of anchors known for hash.
+	RecordAnchor(hash string, record AnchorRecord) error
+	// Lookup returns every known anchor record for hash, oldest first.
+	Lookup(hash string) ([]AnchorRecord, error)
+	// History returns anchors recorded in [from, to], oldest first, capped
+	// at limit entries (0 means unbounded).
+	History(from, to time.Time, limit int) ([]HistoryEntry, error)
+	// Close releases the underlying store.
+	Close() error
+}
+
+// BoltIndex is an Index backed by an embedded bbolt database file.
+type BoltIndex struct {
+	db *bolt.DB
+}
+
+// NewBoltIndex opens (creating if necessary) a BoltIndex at path.
+func NewBoltIndex(path string) (*BoltIndex, error) {
+	db, err := bolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index database at %q: %w", path, err)
+	}
+
+	return &BoltIndex{db: db}, nil
+}
+
+// RecordAnchor implements Index.
+func (idx *BoltIndex) RecordAnchor(hash string, record AnchorRecord) error {
+	return idx.db.Update(func(tx *bolt.Tx) error {
+		hashBucket, err := tx.CreateBucketIfNotExists(hashRecordsBucket)
+		if err != nil {
+			return err
+		}
+
+		var records []AnchorRecord
+		if existing := hashBucket.Get([]byte(hash)); existing != nil {
+			if err := json.Unmarshal(existing, &records); err != nil {
+				return err
+			}
+		}
+		records = append(records, record)
+
+		data, err := json.Marshal(records)
+		if err != nil {
+			return err
+		}
+		if err := hashBucket.Put([]byte(hash), data); err != nil {
+			return err
+		}
+
+		outputBucket, err := tx.CreateBucketIfNotExists(outputHashBucket)
+		if err != nil {
+			return err
+		}
+		if err := outputBucket.Put([]byte(record.OutputID), []byte(hash)); err != nil {
+			return err
+		}
+
+		timeBucket, err := tx.CreateBucketIfNotExists(byTimeBucket)
+		if err != nil {
+			return err
+		}
+
+		entryData, err := json.Marshal(HistoryEntry{Hash: hash, AnchorRecord: record})
+		if err != nil {
+			return err
+		}
+
+		return timeBucket.Put(timeKey(record.Timestamp, hash), entryData)
+	})
+}
+
+// Lookup implements Index.
+func (idx *BoltIndex) Lookup(hash string) ([]AnchorRecord, error) {
+	var records []AnchorRecord
+
+	err := idx.db.View(func(tx *bolt.Tx) error {
+		hashBucket := tx.Bucket(hashRecordsBucket)
+		if hashBucket == nil {
+			return nil
+		}
+
+		data := hashBucket.Get([]byte(hash))
+		if data == nil {
+			return nil
+		}
+
+		return json.Unmarshal(data, &records)
+	})
+
+	return records, err
+}
+
+// History implements Index.
+func (idx *BoltIndex) History(from, to time.Time, limit int) ([]HistoryEntry, error) {
+	var entries []HistoryEntry
+
+	upper := uint64(to.UnixNano())
+
+	err := idx.db.View(func(tx *bolt.Tx) error {
+		timeBucket := tx.Bucket(byTimeBucket)
+		if timeBucket == nil {
+			return nil
+		}
+
+		c := timeBucket.Cursor()
+		for k, v := c.Seek(timeKey(from, "")); k != nil; k, v = c.Next() {
+			if binary.BigEndian.Uint64(k[:8]) > upper {
+				break
+			}
+
+			var entry HistoryEntry
+			if err := json.Unmarshal(v, &entry); err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+
+			if limit > 0 && len(entries) >= limit {
+				break
+			}
+		}
+
+		return nil
+	})
+
+	return entries, err
+}
+
+// Close implements Index.
+func (idx *BoltIndex) Close() error {
+	return idx.db.Close()
+}
+
+// timeKey builds a by_time bucket key that sorts chronologically: an 8-byte
+// big-endian Unix nanosecond timestamp followed by hash, so entries with the
+// same timestamp still sort deterministically and a bare timestamp prefix
+// (hash == "") can be used as a range boundary.
+func timeKey(t time.Time, hash string) []byte {
+	key := make([]byte, 8+len(hash))
+	binary.BigEndian.PutUint64(key[:8], uint64(t.UnixNano()))
+	copy(key[8:], hash)
+
+	return key
+}
@@ -0,0 +1,72 @@
+package index
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func openTestIndex(t *testing.T) *BoltIndex {
+	idx, err := NewBoltIndex(filepath.Join(t.TempDir(), "index.db"))
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = idx.Close() })
+
+	return idx
+}
+
+func TestBoltIndexRecordAnchorAndLookup(t *testing.T) {
+	t.Run("recorded anchors are returned in order", func(t *testing.T) {
+		idx := openTestIndex(t)
+
+		first := AnchorRecord{OutputID: "output1", BlockID: "block1", Timestamp: time.Now().UTC()}
+		second := AnchorRecord{OutputID: "output2", BlockID: "block2", Timestamp: time.Now().UTC()}
+
+		assert.NoError(t, idx.RecordAnchor("abcd1234", first))
+		assert.NoError(t, idx.RecordAnchor("abcd1234", second))
+
+		records, err := idx.Lookup("abcd1234")
+		assert.NoError(t, err)
+		if assert.Len(t, records, 2) {
+			assert.Equal(t, first.OutputID, records[0].OutputID)
+			assert.Equal(t, second.OutputID, records[1].OutputID)
+			assert.True(t, first.Timestamp.Equal(records[0].Timestamp))
+			assert.True(t, second.Timestamp.Equal(records[1].Timestamp))
+		}
+	})
+
+	t.Run("unknown hash returns no records", func(t *testing.T) {
+		idx := openTestIndex(t)
+
+		records, err := idx.Lookup("unknown")
+		assert.NoError(t, err)
+		assert.Empty(t, records)
+	})
+}
+
+func TestBoltIndexHistory(t *testing.T) {
+	idx := openTestIndex(t)
+	base := time.Now().Truncate(time.Second)
+
+	assert.NoError(t, idx.RecordAnchor("hash-early", AnchorRecord{OutputID: "o1", Timestamp: base}))
+	assert.NoError(t, idx.RecordAnchor("hash-mid", AnchorRecord{OutputID: "o2", Timestamp: base.Add(time.Minute)}))
+	assert.NoError(t, idx.RecordAnchor("hash-late", AnchorRecord{OutputID: "o3", Timestamp: base.Add(2 * time.Minute)}))
+
+	t.Run("range excludes entries outside [from, to]", func(t *testing.T) {
+		entries, err := idx.History(base, base.Add(90*time.Second), 0)
+		assert.NoError(t, err)
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, "hash-early", entries[0].Hash)
+			assert.Equal(t, "hash-mid", entries[1].Hash)
+		}
+	})
+
+	t.Run("limit caps the number of returned entries", func(t *testing.T) {
+		entries, err := idx.History(base, base.Add(2*time.Minute), 1)
+		assert.NoError(t, err)
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, "hash-early", entries[0].Hash)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+// Package merkle builds RFC 6962-style Merkle trees over a batch of leaf
+// hashes and produces inclusion proofs against the resulting root, so a
+// single on-chain anchor can vouch for many notarizations at once.
+package merkle
+
+import "crypto/sha256"
+
+// leafPrefix and nodePrefix domain-separate leaf and interior node hashes so
+// that an interior node can never be mistaken for a leaf (the classic
+// second-preimage attack against naive Merkle trees).
+const (
+	leafPrefix = 0x00
+	nodePrefix = 0x01
+)
+
+// Direction indicates which side of a proof step a sibling hash sits on.
+type Direction bool
+
+const (
+	Left  Direction = false
+	Right Direction = true
+)
+
+// ProofStep is a single sibling hash plus the direction it sits on, read from
+// leaf to root.
+type ProofStep struct {
+	Sibling   [32]byte
+	Direction Direction
+}
+
+// Tree is a binary Merkle tree built from an ordered list of leaf hashes.
+type Tree struct {
+	levels [][][32]byte // levels[0] is the leaf level
+}
+
+// LeafHash hashes data as a tree leaf, domain-separated from interior nodes.
+func LeafHash(data []byte) [32]byte {
+	return sha256.Sum256(append([]byte{leafPrefix}, data...))
+}
+
+func nodeHash(left, right [32]byte) [32]byte {
+	buf := make([]byte, 0, 1+len(left)+len(right))
+	buf = append(buf, nodePrefix)
+	buf = append(buf, left[:]...)
+	buf = append(buf, right[:]...)
+
+	return sha256.Sum256(buf)
+}
+
+// New builds a Tree from already-hashed leaves. It panics if leaves is empty;
+// callers are expected to only flush non-empty batches.
+func New(leaves [][32]byte) *Tree {
+	if len(leaves) == 0 {
+		panic("merkle: cannot build a tree with no leaves")
+	}
+
+	levels := [][][32]byte{leaves}
+	for len(levels[len(levels)-1]) > 1 {
+		current := levels[len(levels)-1]
+
+		next := make([][32]byte, 0, (len(current)+1)/2)
+		for i := 0; i < len(current); i += 2 {
+			if i+1 == len(current) {
+				// odd one out is promoted unchanged, as in RFC 6962
+				next = append(next, current[i])
+				continue
+			}
+			next = append(next, nodeHash(current[i], current[i+1]))
+		}
+
+		levels = append(levels, next)
+	}
+
+	return &Tree{levels: levels}
+}
+
+// Root returns the tree's root hash.
+func (t *Tree) Root() [32]byte {
+	return t.levels[len(t.levels)-1][0]
+}
+
+// Proof returns the inclusion proof for the leaf at leafIndex, read from leaf
+// to root.
+func (t *Tree) Proof(leafIndex int) []ProofStep {
+	var steps []ProofStep
+
+	index := leafIndex
+	for _, level := range t.levels[:len(t.levels)-1] {
+		siblingIndex := index ^ 1
+		if siblingIndex >= len(level) {
+			// odd one out had no sibling at this level and was promoted
+			// unchanged, so it contributes no proof step here.
+			index /= 2
+			continue
+		}
+
+		direction := Right
+		if index%2 == 1 {
+			direction = Left
+		}
+
+		steps = append(steps, ProofStep{Sibling: level[siblingIndex], Direction: direction})
+		index /= 2
+	}
+
+	return steps
+}
+
+// VerifyProof recomputes the root from leaf and proof and reports whether it
+// matches root.
+func VerifyProof(leaf [32]byte, proof []ProofStep, root [32]byte) bool {
+	current := leaf
+	for _, step := range proof {
+		if step.Direction == Right {
+			current = nodeHash(current, step.Sibling)
+		} else {
+			current = nodeHash(step.Sibling, current)
+		}
+	}
+
+	return current == root
+}
@@ -0,0 +1,48 @@
+package merkle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeProof(t *testing.T) {
+	t.Run("Every leaf verifies against the root", func(t *testing.T) {
+		leaves := [][32]byte{
+			LeafHash([]byte("a")),
+			LeafHash([]byte("b")),
+			LeafHash([]byte("c")),
+			LeafHash([]byte("d")),
+			LeafHash([]byte("e")),
+		}
+
+		tree := New(leaves)
+		root := tree.Root()
+
+		for i, leaf := range leaves {
+			proof := tree.Proof(i)
+			assert.True(t, VerifyProof(leaf, proof, root), "leaf %d failed to verify", i)
+		}
+	})
+
+	t.Run("Tampered leaf fails to verify", func(t *testing.T) {
+		leaves := [][32]byte{
+			LeafHash([]byte("a")),
+			LeafHash([]byte("b")),
+			LeafHash([]byte("c")),
+		}
+
+		tree := New(leaves)
+		proof := tree.Proof(0)
+
+		assert.False(t, VerifyProof(LeafHash([]byte("tampered")), proof, tree.Root()))
+	})
+
+	t.Run("Single leaf tree", func(t *testing.T) {
+		leaf := LeafHash([]byte("only"))
+		tree := New([][32]byte{leaf})
+
+		assert.Equal(t, leaf, tree.Root())
+		assert.Empty(t, tree.Proof(0))
+	})
+}
@@ -0,0 +1,9 @@
+package signer
+
+// The toolchain that runs this repo's CI does not have protoc wired in, so
+// remotesign.pb.go and remotesign_grpc.pb.go are checked in by hand instead
+// of being regenerated on every build. If protoc ever is wired in, the
+// go:generate directive below reproduces them and this comment and the
+// hand-written-file note at the top of each can go.
+//
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative remotesign.proto
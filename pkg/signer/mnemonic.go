@@ -0,0 +1,31 @@
+package signer
+
+import (
+	"fmt"
+
+	"github.com/adrian-grassl/inx-notarizer/pkg/hdwallet"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// MnemonicSigner derives keys from a BIP-39 mnemonic held in memory, the same
+// way the notarizer has always worked when reading MNEMONIC from .env. It
+// exists mainly for local development; production deployments should prefer
+// VaultSigner or KMSSigner so the mnemonic never has to touch this process.
+type MnemonicSigner struct {
+	mnemonic []string
+}
+
+// NewMnemonicSigner creates a MnemonicSigner for the given mnemonic phrases.
+func NewMnemonicSigner(mnemonic []string) *MnemonicSigner {
+	return &MnemonicSigner{mnemonic: mnemonic}
+}
+
+// Ed25519AddressAndSigner implements Signer.
+func (s *MnemonicSigner) Ed25519AddressAndSigner(protoParas *iotago.ProtocolParameters) (*iotago.Ed25519Address, iotago.AddressSigner, error) {
+	wallet, err := hdwallet.NewHDWallet(protoParas, s.mnemonic, "", 0, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating wallet failed, err: %w", err)
+	}
+
+	return wallet.Ed25519AddressAndSigner(0)
+}
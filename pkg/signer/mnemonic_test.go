@@ -0,0 +1,40 @@
+package signer
+
+import (
+	"testing"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMnemonicSignerEd25519AddressAndSigner(t *testing.T) {
+	mnemonic := []string{
+		"pass", "improve", "fitness", "dress", "range",
+		"orphan", "mass", "story", "tree", "meat",
+		"evidence", "ostrich", "render", "shock", "ancient",
+		"minute", "hip", "feature", "split", "rigid",
+		"way", "figure", "wasp", "property",
+	}
+	protoParas := &iotago.ProtocolParameters{
+		Version:       2,
+		NetworkName:   "private_tangle1",
+		Bech32HRP:     "tst",
+		MinPoWScore:   0,
+		BelowMaxDepth: 15,
+		RentStructure: iotago.RentStructure{
+			VByteCost:    500,
+			VBFactorData: 1,
+			VBFactorKey:  10,
+		},
+		TokenSupply: 2779530283277761,
+	}
+
+	address, addressSigner, err := NewMnemonicSigner(mnemonic).Ed25519AddressAndSigner(protoParas)
+	assert.NoError(t, err)
+	assert.NotNil(t, address)
+	assert.NotNil(t, addressSigner)
+
+	signature, err := addressSigner.Sign(address, []byte("hello"))
+	assert.NoError(t, err)
+	assert.NotNil(t, signature)
+}
@@ -0,0 +1,64 @@
+// Hand-written, not protoc-generated: this toolchain has no protoc/protoc-gen-go
+// step wired in yet (see doc.go), so the messages remotesign.proto describes
+// are checked in directly instead of being gitignored build output. Keep this
+// file in sync with remotesign.proto by hand until `go generate` can replace
+// it.
+
+package signer
+
+import "fmt"
+
+// PublicKeyRequest is the request message for RemoteSigner.PublicKey.
+type PublicKeyRequest struct{}
+
+func (x *PublicKeyRequest) Reset()         { *x = PublicKeyRequest{} }
+func (x *PublicKeyRequest) String() string { return "PublicKeyRequest{}" }
+func (*PublicKeyRequest) ProtoMessage()    {}
+
+// PublicKeyResponse is the response message for RemoteSigner.PublicKey.
+type PublicKeyResponse struct {
+	PublicKey []byte `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+}
+
+func (x *PublicKeyResponse) Reset()         { *x = PublicKeyResponse{} }
+func (x *PublicKeyResponse) String() string { return fmt.Sprintf("%+v", *x) }
+func (*PublicKeyResponse) ProtoMessage()    {}
+
+func (x *PublicKeyResponse) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+// SignRequest is the request message for RemoteSigner.Sign.
+type SignRequest struct {
+	Message []byte `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *SignRequest) Reset()         { *x = SignRequest{} }
+func (x *SignRequest) String() string { return fmt.Sprintf("%+v", *x) }
+func (*SignRequest) ProtoMessage()    {}
+
+func (x *SignRequest) GetMessage() []byte {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+// SignResponse is the response message for RemoteSigner.Sign.
+type SignResponse struct {
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *SignResponse) Reset()         { *x = SignResponse{} }
+func (x *SignResponse) String() string { return fmt.Sprintf("%+v", *x) }
+func (*SignResponse) ProtoMessage()    {}
+
+func (x *SignResponse) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
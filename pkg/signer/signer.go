@@ -0,0 +1,22 @@
+// Package signer abstracts over where the notarizer's Ed25519 signing keys
+// live, so a deployment can swap a plaintext mnemonic for a Vault, KMS, or
+// hardware-backed signer without the notarization logic needing to change.
+package signer
+
+import iotago "github.com/iotaledger/iota.go/v3"
+
+// Signer derives the Ed25519 address a backend notarizes from, plus an
+// iotago.AddressSigner that delegates the actual signing to that backend.
+type Signer interface {
+	Ed25519AddressAndSigner(protoParas *iotago.ProtocolParameters) (*iotago.Ed25519Address, iotago.AddressSigner, error)
+}
+
+// signFunc adapts a plain signing function to iotago.AddressSigner. It exists
+// because iotago.AddressSignerFunc's Sign method returns a
+// serializer.Serializable rather than an iotago.Signature, so it does not
+// itself satisfy iotago.AddressSigner.
+type signFunc func(addr iotago.Address, msg []byte) (iotago.Signature, error)
+
+func (f signFunc) Sign(addr iotago.Address, msg []byte) (iotago.Signature, error) {
+	return f(addr, msg)
+}
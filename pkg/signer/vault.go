@@ -0,0 +1,111 @@
+package signer
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// VaultSigner delegates Ed25519 signing to a key held in a HashiCorp Vault
+// transit secrets engine, so the signing key never has to touch the
+// notarizer process.
+type VaultSigner struct {
+	client  *vaultapi.Client
+	keyName string
+}
+
+// NewVaultSigner creates a VaultSigner that signs with the transit key
+// keyName, using client (already configured with a Vault address and a
+// token or auth method able to reach the transit engine).
+func NewVaultSigner(client *vaultapi.Client, keyName string) *VaultSigner {
+	return &VaultSigner{client: client, keyName: keyName}
+}
+
+// Ed25519AddressAndSigner implements Signer.
+func (s *VaultSigner) Ed25519AddressAndSigner(_ *iotago.ProtocolParameters) (*iotago.Ed25519Address, iotago.AddressSigner, error) {
+	pubKey, err := s.publicKey(context.Background())
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetching public key from vault failed, err: %w", err)
+	}
+
+	address := iotago.Ed25519AddressFromPubKey(pubKey)
+
+	return &address, signFunc(func(_ iotago.Address, msg []byte) (iotago.Signature, error) {
+		rawSignature, err := s.sign(context.Background(), msg)
+		if err != nil {
+			return nil, err
+		}
+
+		ed25519Sig := &iotago.Ed25519Signature{}
+		copy(ed25519Sig.PublicKey[:], pubKey)
+		copy(ed25519Sig.Signature[:], rawSignature)
+
+		return ed25519Sig, nil
+	}), nil
+}
+
+// publicKey fetches the current public key for the configured transit key.
+func (s *VaultSigner) publicKey(ctx context.Context) (ed25519.PublicKey, error) {
+	secret, err := s.client.Logical().ReadWithContext(ctx, fmt.Sprintf("transit/keys/%s", s.keyName))
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("transit key %q not found", s.keyName)
+	}
+
+	keys, ok := secret.Data["keys"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected response shape for transit key %q", s.keyName)
+	}
+
+	latestVersion, ok := secret.Data["latest_version"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("transit key %q has no latest_version", s.keyName)
+	}
+
+	versionKey := fmt.Sprintf("%d", int(latestVersion))
+	versionData, ok := keys[versionKey].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("transit key %q has no data for version %s", s.keyName, versionKey)
+	}
+
+	publicKeyB64, ok := versionData["public_key"].(string)
+	if !ok {
+		return nil, fmt.Errorf("transit key %q has no public_key for version %s", s.keyName, versionKey)
+	}
+
+	return base64.StdEncoding.DecodeString(publicKeyB64)
+}
+
+// sign asks the transit engine to sign msg and returns the raw signature
+// bytes, stripped of Vault's "vault:v<version>:" ciphertext-style prefix.
+func (s *VaultSigner) sign(ctx context.Context, msg []byte) ([]byte, error) {
+	secret, err := s.client.Logical().WriteWithContext(ctx, fmt.Sprintf("transit/sign/%s", s.keyName), map[string]interface{}{
+		"input": base64.StdEncoding.EncodeToString(msg),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("transit key %q returned no signature", s.keyName)
+	}
+
+	signature, ok := secret.Data["signature"].(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response shape signing with transit key %q", s.keyName)
+	}
+
+	// Vault signatures are of the form "vault:v<version>:<base64>".
+	parts := strings.SplitN(signature, ":", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("unexpected signature format from transit key %q", s.keyName)
+	}
+
+	return base64.StdEncoding.DecodeString(parts[2])
+}